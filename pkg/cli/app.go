@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/x1t/sv/pkg/supervisor"
 )
@@ -37,7 +40,10 @@ func (app *CLIApp) Run() error {
 	}
 
 	// 对于与Supervisor交互的命令，检测并开启RPC功能
-	if command == "status" || command == "list" || command == "start" || command == "stop" || command == "restart" {
+	if command == "status" || command == "list" || command == "start" || command == "stop" || command == "restart" ||
+		command == "signal" || command == "tail" || command == "logs" ||
+		command == "reread" || command == "update" || command == "info" ||
+		command == "supervisord" || command == "show" {
 		// 尝试检测并开启RPC功能
 		cd := supervisor.NewConfigDetector()
 		err := cd.DetectAndEnableRPC()
@@ -53,10 +59,27 @@ func (app *CLIApp) Run() error {
 
 	// 创建Supervisor客户端
 	client := supervisor.NewRPCClient(host, username, password)
+	// supervisord刚重启或代理暂时不可用时重试只读调用
+	client.SetRetryPolicy(supervisor.DefaultRetryPolicy)
+
+	// 配置HTTPS端点的TLS选项
+	if tlsOpts := cd.ReadTLSOptions(); !tlsOpts.IsZero() {
+		if err := client.SetTLS(tlsOpts); err != nil {
+			fmt.Printf("❌ TLS配置无效: %v\n", err)
+			return err
+		}
+		if tlsOpts.InsecureSkipVerify {
+			fmt.Println("⚠️  已跳过服务端证书校验 (SUPERVISOR_INSECURE_SKIP_VERIFY)，请勿在生产环境使用")
+		}
+	}
+
+	// Ctrl-C 或 SIGTERM 时取消正在进行的RPC调用
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	switch command {
 	case "status", "list":
-		app.renderer.ShowStatus(client)
+		return app.renderer.ShowStatus(ctx, client, args)
 	case "start", "stop", "restart":
 		if len(args) == 0 {
 			fmt.Printf("用法: sv %s <进程序号|进程名称|范围>\n", command)
@@ -67,7 +90,30 @@ func (app *CLIApp) Run() error {
 			fmt.Printf("  sv %s 1-5     # 控制序号1到5的进程\n", command)
 			return fmt.Errorf("参数不足")
 		}
-		app.renderer.ControlProcesses(client, command, args)
+		return app.renderer.ControlProcesses(ctx, client, command, args)
+	case "signal":
+		if len(args) < 2 {
+			fmt.Println("用法: sv signal <信号名称|信号编号> <进程序号|进程名称|范围|group:*|all>")
+			fmt.Println("示例:")
+			fmt.Println("  sv signal HUP 1          # 向序号为1的进程发送SIGHUP")
+			fmt.Println("  sv signal USR1 web:*     # 向web组的所有进程发送SIGUSR1")
+			fmt.Println("  sv signal 15 1 3-5       # 使用信号编号")
+			fmt.Println("  sv signal HUP all        # 向所有进程发送信号")
+			return fmt.Errorf("参数不足")
+		}
+		return app.renderer.SignalProcesses(ctx, client, args[0], args[1:])
+	case "supervisord":
+		return app.renderer.ControlDaemon(ctx, client, args)
+	case "info":
+		return app.renderer.ShowInfo(ctx, client)
+	case "show":
+		return app.renderer.ShowProcess(ctx, client, args)
+	case "reread", "update":
+		return app.renderer.UpdateConfig(ctx, client, command == "update", args)
+	case "tail":
+		return app.renderer.TailLog(ctx, client, args)
+	case "logs":
+		return app.renderer.MergeLogs(ctx, client, args)
 	case "daemon":
 		// 守护进程模式，由系统服务管理器调用
 		sm := supervisor.NewServiceManager()
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/x1t/sv/pkg/utils"
+)
+
+// defaultConfirmThreshold 停止或重启超过该数量的进程时需要确认
+const defaultConfirmThreshold = 5
+
+// confirmThreshold 返回需要确认的进程数量，可通过 SV_CONFIRM_THRESHOLD 修改
+func confirmThreshold() int {
+	value := os.Getenv("SV_CONFIRM_THRESHOLD")
+	if value == "" {
+		return defaultConfirmThreshold
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		fmt.Printf("⚠️  SV_CONFIRM_THRESHOLD 无效 (%s)，使用默认值 %d\n", value, defaultConfirmThreshold)
+		return defaultConfirmThreshold
+	}
+	return n
+}
+
+// needsConfirmation 判断停止或重启selected个进程前是否需要确认
+// 数量超过threshold，或者选中了全部total个进程时需要确认；只有一个进程时不算选中全部
+func needsConfirmation(action string, selected, total, threshold int) bool {
+	if action != "stop" && action != "restart" {
+		return false
+	}
+	return selected > threshold || (total > 1 && selected == total)
+}
+
+// actionVerb 返回操作的中文名称
+func actionVerb(action string) string {
+	switch action {
+	case "start":
+		return "启动"
+	case "stop":
+		return "停止"
+	case "restart":
+		return "重启"
+	default:
+		return action
+	}
+}
+
+// printSelection 按解析后的顺序列出选中的进程及其序号和当前状态
+func printSelection(names []string, processes []utils.ProcessInfo) {
+	byName := make(map[string]utils.ProcessInfo, len(processes))
+	for _, p := range processes {
+		byName[p.Name] = p
+	}
+	for _, name := range names {
+		p, ok := byName[name]
+		if !ok {
+			fmt.Printf("    -  %s\n", name)
+			continue
+		}
+		fmt.Printf("  %3d  %-30s %s\n", p.Index, name, p.StateName)
+	}
+}
+
+// confirmSelection 选中的进程较多或为全部进程时列出进程并询问是否继续
+// 用户拒绝，或者标准输入不是终端无法询问时返回错误
+func confirmSelection(action string, names []string, processes []utils.ProcessInfo) error {
+	if !needsConfirmation(action, len(names), len(processes), confirmThreshold()) {
+		return nil
+	}
+	scope := ""
+	if len(processes) > 1 && len(names) == len(processes) {
+		scope = " (全部进程)"
+	}
+	fmt.Printf("⚠️  将要%s以下 %d 个进程%s:\n", actionVerb(action), len(names), scope)
+	printSelection(names, processes)
+	return confirmAction("确定要继续吗?")
+}
+
+// printDryRun 输出将要执行的操作，滚动重启时同时列出每个批次
+func printDryRun(action string, names []string, processes []utils.ProcessInfo, rollingBatch int) {
+	fmt.Printf("🔎 将要%s以下 %d 个进程:\n", actionVerb(action), len(names))
+	printSelection(names, processes)
+	if rollingBatch > 0 {
+		total := (len(names) + rollingBatch - 1) / rollingBatch
+		for start, number := 0, 1; start < len(names); start, number = start+rollingBatch, number+1 {
+			end := min(start+rollingBatch, len(names))
+			fmt.Printf("  第 %d/%d 批: %s\n", number, total, strings.Join(names[start:end], ", "))
+		}
+	}
+	fmt.Println("\n🔎 演练模式，未执行任何操作")
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/x1t/sv/pkg/utils"
+)
+
+// TestNeedsConfirmation 测试停止和重启较多进程或全部进程时需要确认
+func TestNeedsConfirmation(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   string
+		selected int
+		total    int
+		expected bool
+	}{
+		{"少量进程", "stop", 3, 20, false},
+		{"等于阈值", "restart", 5, 20, false},
+		{"超过阈值", "stop", 20, 30, true},
+		{"全部进程", "restart", 2, 2, true},
+		{"只有一个进程", "stop", 1, 1, false},
+		{"启动不需要确认", "start", 20, 20, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, needsConfirmation(tt.action, tt.selected, tt.total, 5))
+		})
+	}
+}
+
+// TestConfirmThreshold 测试通过环境变量修改确认阈值
+func TestConfirmThreshold(t *testing.T) {
+	t.Setenv("SV_CONFIRM_THRESHOLD", "")
+	assert.Equal(t, defaultConfirmThreshold, confirmThreshold())
+
+	t.Setenv("SV_CONFIRM_THRESHOLD", "10")
+	assert.Equal(t, 10, confirmThreshold())
+
+	t.Setenv("SV_CONFIRM_THRESHOLD", "many")
+	assert.Equal(t, defaultConfirmThreshold, confirmThreshold())
+}
+
+// TestConfirmSelection_NotTerminal 测试标准输入不是终端时直接返回错误，而不是等待输入
+func TestConfirmSelection_NotTerminal(t *testing.T) {
+	isTerminal := stdinIsTerminal
+	t.Cleanup(func() { stdinIsTerminal = isTerminal })
+	stdinIsTerminal = func() bool { return false }
+	t.Setenv("SV_CONFIRM_THRESHOLD", "")
+
+	processes := []utils.ProcessInfo{{Index: 1, Name: "web:web_00"}, {Index: 2, Name: "web:web_01"}, {Index: 3, Name: "cron"}}
+	assert.NoError(t, confirmSelection("stop", []string{"cron"}, processes))
+	assert.ErrorIs(t, confirmSelection("stop", []string{"web:web_00", "web:web_01", "cron"}, processes), errConfirmRequired)
+}
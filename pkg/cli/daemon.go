@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/x1t/sv/pkg/supervisor"
+)
+
+// ControlDaemon 重启、重新加载或关闭supervisord自身
+func (cr *CLIRenderer) ControlDaemon(ctx context.Context, client *supervisor.RPCClient, args []string) error {
+	fs := newFlagSet("supervisord")
+	yes := fs.Bool("yes", false, "不询问直接执行")
+	fs.BoolVar(yes, "y", false, "不询问直接执行")
+	rest, err := parseFlags(fs, args)
+	if err != nil || len(rest) != 1 {
+		printDaemonUsage()
+		if err == nil {
+			err = fmt.Errorf("参数错误")
+		}
+		return err
+	}
+
+	action := rest[0]
+	var description string
+	switch action {
+	case supervisor.DaemonRestart:
+		description = "重启supervisord"
+	case supervisor.DaemonReload:
+		description = "重新加载supervisord配置"
+	case supervisor.DaemonShutdown:
+		description = "关闭supervisord"
+	default:
+		printDaemonUsage()
+		return fmt.Errorf("未知操作: %s", action)
+	}
+
+	// 三种操作都会停止supervisord管理的所有进程
+	fmt.Printf("⚠️  %s会停止它管理的所有进程 (%s)\n", description, client.Host())
+	if !*yes {
+		if err := confirmAction(fmt.Sprintf("确定要%s吗?", description)); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("🔄 正在%s...\n", description)
+	dc := supervisor.NewDaemonController(client)
+	// 系统服务管理器只能控制本机的supervisord
+	dc.SetInitFallback(client.IsLocal())
+
+	result, err := dc.Run(ctx, action)
+	if err != nil {
+		if ctx.Err() != nil {
+			fmt.Println("⏹️ 操作已取消")
+			return ctx.Err()
+		}
+		fmt.Printf("❌ %s失败: %v\n", description, err)
+		if !client.IsLocal() {
+			fmt.Println("💡 提示: 远程supervisord无法回退到systemctl/service，请登录主机处理")
+		}
+		return err
+	}
+
+	if action == supervisor.DaemonShutdown {
+		fmt.Printf("✅ supervisord已关闭 (通过 %s)\n", result.Via)
+	} else {
+		fmt.Printf("✅ supervisord已恢复运行，状态: %s (通过 %s)\n", result.State.Name, result.Via)
+	}
+	return nil
+}
+
+// printDaemonUsage 打印supervisord命令的用法
+func printDaemonUsage() {
+	fmt.Println("用法: sv supervisord <restart|reload|shutdown> [-y]")
+	fmt.Println("示例:")
+	fmt.Println("  sv supervisord reload      # 重新加载配置并重启所有进程 (同 supervisorctl reload)")
+	fmt.Println("  sv supervisord restart     # 重启supervisord")
+	fmt.Println("  sv supervisord shutdown    # 关闭supervisord")
+}
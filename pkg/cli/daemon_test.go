@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/x1t/sv/pkg/supervisor"
+)
+
+// TestControlDaemon_NotTerminal 测试标准输入不是终端并且没有 -y 时不执行操作，并返回错误
+func TestControlDaemon_NotTerminal(t *testing.T) {
+	isTerminal := stdinIsTerminal
+	t.Cleanup(func() { stdinIsTerminal = isTerminal })
+	stdinIsTerminal = func() bool { return false }
+
+	// 地址不可连接，确认失败时不应发出任何请求
+	client := supervisor.NewRPCClient("http://127.0.0.1:1/RPC2", "", "")
+	err := NewCLIRenderer().ControlDaemon(context.Background(), client, []string{"restart"})
+	assert.ErrorIs(t, err, errConfirmRequired)
+}
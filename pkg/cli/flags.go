@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/x1t/sv/pkg/utils"
+)
+
+// newFlagSet 创建子命令的选项解析器，解析错误由调用方输出
+func newFlagSet(command string) *flag.FlagSet {
+	fs := flag.NewFlagSet("sv "+command, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseFlags 解析子命令参数，允许选项出现在位置参数之后（如 sv tail 1 -f）
+// 返回去掉选项后的位置参数；"--" 之后的参数全部按位置参数处理
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		// flag 在 "--" 处停止解析并将其丢弃
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// stringList 可以重复指定的字符串选项
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// addSelectorFlags 为使用选项解析器的命令注册 --failed 和 -x/--exclude，
+// 返回的函数将它们转换回 utils.ParseProcessIndices 能识别的进程参数
+func addSelectorFlags(fs *flag.FlagSet) func(positional []string) []string {
+	failed := fs.Bool("failed", false, "选择FATAL或BACKOFF状态的进程")
+	var excludes stringList
+	fs.Var(&excludes, "x", "排除匹配的进程")
+	fs.Var(&excludes, "exclude", "排除匹配的进程")
+	return func(positional []string) []string {
+		args := positional
+		if *failed {
+			args = append(args, utils.SelectFailed)
+		}
+		for _, exclude := range excludes {
+			args = append(args, "!"+exclude)
+		}
+		return args
+	}
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/x1t/sv/pkg/supervisor"
+)
+
+// ShowInfo 显示所连接的supervisord的状态、PID、版本和配置文件
+func (cr *CLIRenderer) ShowInfo(ctx context.Context, client *supervisor.RPCClient) error {
+	info, err := client.ServerInfo(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			fmt.Println("⏹️ 操作已取消")
+			return nil
+		}
+		fmt.Printf("❌ 无法连接Supervisor (%s): %v\n", client.Host(), err)
+		fmt.Println("💡 提示: 请确保supervisord正在运行，并检查SUPERVISOR_HOST等环境变量")
+		return err
+	}
+
+	configFile := "未知（远程主机）"
+	if client.IsLocal() {
+		configFile = supervisor.NewConfigDetector().ConfigFilePath(info.PID)
+		if configFile == "" {
+			configFile = "未找到"
+		}
+	}
+
+	fmt.Println("\n🖥️  Supervisor信息")
+	fmt.Printf("  连接地址: %s\n", client.Host())
+	if info.Transport == supervisor.TransportRPC {
+		fmt.Printf("  连接方式: %s\n", info.Transport)
+	} else {
+		fmt.Printf("  连接方式: %s (RPC不可用，已回退)\n", info.Transport)
+	}
+	fmt.Printf("  运行状态: %s%s\x1b[0m\n", supervisorStateColor(info.State.Code), info.State.Name)
+	fmt.Printf("  PID:      %d\n", info.PID)
+	fmt.Printf("  版本:     %s\n", valueOrUnknown(info.Version))
+	fmt.Printf("  API版本:  %s\n", valueOrUnknown(info.APIVersion))
+	fmt.Printf("  标识:     %s\n", valueOrUnknown(info.Identification))
+	fmt.Printf("  配置文件: %s\n", configFile)
+	return nil
+}
+
+// supervisorStateColor 根据supervisord状态获取颜色
+func supervisorStateColor(code int) string {
+	switch code {
+	case supervisor.SupervisorStateRunning:
+		return "\x1b[32m" // 绿色
+	case supervisor.SupervisorStateRestarting:
+		return "\x1b[33m" // 黄色
+	default:
+		return "\x1b[31m" // 红色
+	}
+}
+
+// valueOrUnknown 空值显示为"未知"
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "未知"
+	}
+	return value
+}
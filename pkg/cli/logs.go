@@ -0,0 +1,353 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/x1t/sv/pkg/supervisor"
+	"github.com/x1t/sv/pkg/utils"
+)
+
+// defaultTailBytes 默认显示的日志字节数，与 supervisorctl tail 一致
+const defaultTailBytes = 1600
+
+// logOverflowNotice 日志增长过快、有内容被跳过时插入的提示行
+const logOverflowNotice = "⚠️  日志增长过快，部分内容已跳过"
+
+// logView 日志的显示和过滤选项
+type logView struct {
+	raw    bool // 不格式化结构化日志
+	filter utils.LogFilter
+}
+
+// addLogViewFlags 注册日志显示相关的选项，返回的函数在解析后生成logView
+func addLogViewFlags(fs *flag.FlagSet) func() (logView, error) {
+	raw := fs.Bool("raw", false, "原样输出，不格式化JSON/logfmt日志")
+	level := fs.String("level", "", "只显示不低于该级别的结构化日志")
+	grep := fs.String("grep", "", "只显示消息或字段匹配该正则的日志")
+	return func() (logView, error) {
+		view := logView{raw: *raw, filter: utils.LogFilter{MinLevel: utils.LogLevelUnknown}}
+		if *level != "" {
+			view.filter.MinLevel = utils.ParseLogLevel(*level)
+			if view.filter.MinLevel == utils.LogLevelUnknown {
+				return view, fmt.Errorf("未知的日志级别: %s (可选: trace/debug/info/warn/error/fatal)", *level)
+			}
+		}
+		if *grep != "" {
+			re, err := regexp.Compile(*grep)
+			if err != nil {
+				return view, fmt.Errorf("无效的正则表达式: %v", err)
+			}
+			view.filter.Grep = re
+		}
+		return view, nil
+	}
+}
+
+// render 过滤并格式化一行日志，返回false表示该行被过滤掉
+func (v logView) render(line string) (string, bool) {
+	if line == logOverflowNotice {
+		return line, true
+	}
+	entry := utils.ParseLogLine(line)
+	if !v.filter.Match(entry) {
+		return "", false
+	}
+	if v.raw {
+		return line, true
+	}
+	return utils.FormatLogEntry(entry), true
+}
+
+// parseLogArgs 解析日志命令的参数，出错时输出原因和用法
+func parseLogArgs(fs *flag.FlagSet, args []string, length *int, view func() (logView, error), usage func()) ([]string, logView, error) {
+	selectorArgs := addSelectorFlags(fs)
+	selectors, err := parseFlags(fs, args)
+	if err != nil {
+		fmt.Printf("❌ 参数错误: %v\n", err)
+		usage()
+		return nil, logView{}, err
+	}
+	selectors = selectorArgs(selectors)
+	if len(selectors) == 0 {
+		usage()
+		return nil, logView{}, fmt.Errorf("参数不足")
+	}
+	if *length <= 0 {
+		fmt.Printf("❌ -n 必须大于0\n")
+		return nil, logView{}, fmt.Errorf("无效的字节数: %d", *length)
+	}
+	v, err := view()
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return nil, logView{}, err
+	}
+	return selectors, v, nil
+}
+
+// TailLog 显示单个进程的日志末尾，-f 时持续跟踪新内容
+func (cr *CLIRenderer) TailLog(ctx context.Context, client *supervisor.RPCClient, args []string) error {
+	fs := newFlagSet("tail")
+	follow := fs.Bool("f", false, "持续跟踪日志")
+	stderr := fs.Bool("stderr", false, "显示标准错误日志")
+	length := fs.Int("n", defaultTailBytes, "显示的字节数")
+	view := addLogViewFlags(fs)
+	selectors, v, err := parseLogArgs(fs, args, length, view, printTailUsage)
+	if err != nil {
+		return err
+	}
+
+	processNames, _, err := resolveLogTargets(ctx, client, selectors)
+	if err != nil || len(processNames) == 0 {
+		return err
+	}
+	if len(processNames) != 1 {
+		fmt.Printf("❌ tail 只能查看一个进程的日志，当前选中了 %d 个: %v\n", len(processNames), processNames)
+		fmt.Println("💡 提示: 同时查看多个进程请使用 'sv logs'")
+		return fmt.Errorf("选中了多个进程")
+	}
+
+	stream := supervisor.LogStdout
+	if *stderr {
+		stream = supervisor.LogStderr
+	}
+	source := &logSource{follower: supervisor.NewLogFollower(client, processNames[0], stream)}
+
+	chunk, err := source.follower.Start(ctx, *length)
+	if err != nil {
+		return tailError(ctx, processNames[0], err)
+	}
+	source.print(v, source.lines(chunk))
+	if !*follow {
+		source.print(v, source.flush())
+		return nil
+	}
+
+	err = source.follower.Follow(ctx, supervisor.DefaultLogPollInterval, func(chunk supervisor.LogChunk) error {
+		if chunk.Rotated {
+			fmt.Fprintln(os.Stderr, "🔄 日志文件已轮转，从头开始读取")
+		}
+		source.print(v, source.lines(chunk))
+		return nil
+	})
+	source.print(v, source.flush())
+	return tailError(ctx, processNames[0], err)
+}
+
+// resolveLogTargets 将进程参数解析为进程名，同时返回进程列表用于着色
+func resolveLogTargets(ctx context.Context, client *supervisor.RPCClient, selectors []string) ([]string, []utils.ProcessInfo, error) {
+	processes, err := client.GetAllProcesses(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			fmt.Println("⏹️ 操作已取消")
+			return nil, nil, nil
+		}
+		fmt.Printf("❌ 获取进程信息失败: %v\n", err)
+		return nil, nil, err
+	}
+
+	processNames, err := utils.ParseProcessIndices(selectors, processes)
+	if err != nil {
+		fmt.Printf("❌ 解析进程参数失败: %v\n", err)
+		return nil, nil, err
+	}
+	return processNames, processes, nil
+}
+
+// logSource 合并日志视图中的一个日志流
+type logSource struct {
+	follower *supervisor.LogFollower
+	prefix   string
+	partial  string // 尚未以换行结束的内容，等下次读取补全后再输出
+}
+
+// lines 将新读取的内容拆分为完整的行，不完整的末行留到下次输出
+func (s *logSource) lines(chunk supervisor.LogChunk) []string {
+	var lines []string
+	if (chunk.Rotated || chunk.Overflow) && s.partial != "" {
+		// 轮转或跳过内容后无法与之前的半行拼接，直接输出
+		lines = append(lines, s.partial)
+		s.partial = ""
+	}
+	if chunk.Overflow {
+		lines = append(lines, logOverflowNotice)
+	}
+
+	data := s.partial + chunk.Data
+	parts := strings.Split(data, "\n")
+	s.partial = parts[len(parts)-1]
+	return append(lines, parts[:len(parts)-1]...)
+}
+
+// flush 返回剩余的不完整行
+func (s *logSource) flush() []string {
+	if s.partial == "" {
+		return nil
+	}
+	line := s.partial
+	s.partial = ""
+	return []string{line}
+}
+
+// print 按显示选项输出带前缀的日志行
+func (s *logSource) print(v logView, lines []string) {
+	for _, line := range lines {
+		if rendered, ok := v.render(line); ok {
+			fmt.Printf("%s%s\n", s.prefix, rendered)
+		}
+	}
+}
+
+// logPrefix 生成 "group:name |" 前缀，颜色与进程状态一致，标准错误带 "!" 标记
+func logPrefix(name string, width int, state int, stream supervisor.LogStream) string {
+	marker := " "
+	if stream == supervisor.LogStderr {
+		marker = "\x1b[31m!" + utils.GetColorByState(state)
+	}
+	return fmt.Sprintf("%s%-*s %s|\x1b[0m ", utils.GetColorByState(state), width, name, marker)
+}
+
+// MergeLogs 将多个进程的标准输出和标准错误合并为一个输出流
+func (cr *CLIRenderer) MergeLogs(ctx context.Context, client *supervisor.RPCClient, args []string) error {
+	fs := newFlagSet("logs")
+	noFollow := fs.Bool("no-follow", false, "只显示当前日志，不持续跟踪")
+	stdoutOnly := fs.Bool("stdout", false, "只显示标准输出")
+	stderrOnly := fs.Bool("stderr", false, "只显示标准错误")
+	length := fs.Int("n", defaultTailBytes, "每个日志初始显示的字节数")
+	view := addLogViewFlags(fs)
+	selectors, v, err := parseLogArgs(fs, args, length, view, printLogsUsage)
+	if err != nil {
+		return err
+	}
+
+	streams := []supervisor.LogStream{supervisor.LogStdout, supervisor.LogStderr}
+	switch {
+	case *stdoutOnly && *stderrOnly:
+	case *stdoutOnly:
+		streams = streams[:1]
+	case *stderrOnly:
+		streams = streams[1:]
+	}
+
+	processNames, processes, err := resolveLogTargets(ctx, client, selectors)
+	if err != nil || len(processNames) == 0 {
+		return err
+	}
+
+	states := make(map[string]int, len(processes))
+	for _, proc := range processes {
+		states[proc.Name] = proc.State
+	}
+	width := 0
+	for _, name := range processNames {
+		width = max(width, len(name))
+	}
+
+	var mu sync.Mutex
+	emit := func(source *logSource, lines []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		source.print(v, lines)
+	}
+
+	// 先依次输出每个日志的末尾部分
+	var sources []*logSource
+	for _, name := range processNames {
+		for _, stream := range streams {
+			source := &logSource{
+				follower: supervisor.NewLogFollower(client, name, stream),
+				prefix:   logPrefix(name, width, states[name], stream),
+			}
+			chunk, err := source.follower.Start(ctx, *length)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				if errors.Is(err, supervisor.ErrNoFile) && stream == supervisor.LogStderr {
+					// redirect_stderr 或未配置标准错误日志时没有单独的文件
+					continue
+				}
+				fmt.Printf("⚠️  无法读取进程 %s 的%s日志: %v\n", name, stream, err)
+				continue
+			}
+			emit(source, source.lines(chunk))
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("没有可读取的日志")
+	}
+
+	if *noFollow {
+		for _, source := range sources {
+			emit(source, source.flush())
+		}
+		return nil
+	}
+
+	// 每个日志流独立轮询，按行输出避免不同进程的内容交错在同一行
+	var wg sync.WaitGroup
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source *logSource) {
+			defer wg.Done()
+			err := source.follower.Follow(ctx, supervisor.DefaultLogPollInterval, func(chunk supervisor.LogChunk) error {
+				emit(source, source.lines(chunk))
+				return nil
+			})
+			emit(source, source.flush())
+			if err != nil && ctx.Err() == nil {
+				mu.Lock()
+				fmt.Printf("%s❌ 停止跟踪: %v\n", source.prefix, err)
+				mu.Unlock()
+			}
+		}(source)
+	}
+	wg.Wait()
+	return nil
+}
+
+// tailError 输出读取日志失败的原因，用户中断不视为错误
+func tailError(ctx context.Context, name string, err error) error {
+	if err == nil || ctx.Err() != nil {
+		return nil
+	}
+	fmt.Printf("\n❌ 读取进程 %s 的日志失败: %v\n", name, err)
+	switch {
+	case errors.Is(err, supervisor.ErrNoFile):
+		fmt.Println("     💡 该进程没有配置日志文件，或日志文件尚未创建")
+	case errors.Is(err, supervisor.ErrBadName):
+		fmt.Println("     💡 进程不存在，请使用 'sv status' 查看可用的进程")
+	}
+	return err
+}
+
+// printTailUsage 打印tail命令的用法
+func printTailUsage() {
+	fmt.Println("用法: sv tail <进程序号|进程名称> [-f] [--stderr] [-n 字节数] [--level 级别] [--grep 正则] [--raw]")
+	fmt.Println("示例:")
+	fmt.Println("  sv tail 1             # 查看序号为1的进程最后1600字节的输出")
+	fmt.Println("  sv tail myapp -f      # 持续跟踪myapp的输出")
+	fmt.Println("  sv tail 2 --stderr    # 查看标准错误日志")
+	fmt.Println("  sv tail 3 -n 8192     # 查看最后8192字节")
+	fmt.Println("  sv tail api -f --level warn           # 只看warn及以上级别的JSON/logfmt日志")
+	fmt.Println("  sv tail api --grep 'user_id=42'       # 只看消息或字段匹配的日志")
+}
+
+// printLogsUsage 打印logs命令的用法
+func printLogsUsage() {
+	fmt.Println("用法: sv logs <进程序号|进程名称|范围> [--no-follow] [--stdout|--stderr] [-n 字节数] [--level 级别] [--grep 正则] [--raw]")
+	fmt.Println("示例:")
+	fmt.Println("  sv logs 1-5           # 合并跟踪序号1到5的进程的输出")
+	fmt.Println("  sv logs web api       # 合并跟踪多个进程")
+	fmt.Println("  sv logs 1-5 --stderr  # 只看标准错误")
+	fmt.Println("  sv logs 2 --no-follow # 只显示当前日志")
+	fmt.Println("  sv logs 1-5 --level error # 只看error及以上级别的结构化日志")
+	fmt.Println("  sv logs 'api-*' -x api-worker  # 支持通配符、all、state=、--failed 和排除")
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/x1t/sv/pkg/supervisor"
+)
+
+// TestLogSource_Lines 测试按行拆分并保留不完整的末行
+func TestLogSource_Lines(t *testing.T) {
+	source := &logSource{}
+
+	assert.Equal(t, []string{"a", "b"}, source.lines(supervisor.LogChunk{Data: "a\nb\nc"}))
+	assert.Equal(t, []string{"cd"}, source.lines(supervisor.LogChunk{Data: "d\n"}))
+	assert.Empty(t, source.lines(supervisor.LogChunk{Data: "e"}))
+	assert.Equal(t, []string{"e"}, source.flush())
+	assert.Empty(t, source.flush())
+}
+
+// TestLogSource_LinesRotated 测试轮转或跳过内容时不与之前的半行拼接
+func TestLogSource_LinesRotated(t *testing.T) {
+	source := &logSource{}
+	source.lines(supervisor.LogChunk{Data: "old"})
+	assert.Equal(t, []string{"old", "new"}, source.lines(supervisor.LogChunk{Data: "new\n", Rotated: true}))
+
+	source.lines(supervisor.LogChunk{Data: "half"})
+	lines := source.lines(supervisor.LogChunk{Data: "tail\n", Overflow: true})
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "half", lines[0])
+	assert.Equal(t, "tail", lines[2])
+}
+
+// TestLogPrefix 测试前缀对齐和标准错误标记
+func TestLogPrefix(t *testing.T) {
+	stdout := logPrefix("web:web_00", 12, 20, supervisor.LogStdout)
+	stderr := logPrefix("web:web_00", 12, 20, supervisor.LogStderr)
+	assert.True(t, strings.HasPrefix(stdout, "\x1b[32mweb:web_00  "))
+	assert.Contains(t, stdout, " |")
+	assert.Contains(t, stderr, "!")
+	assert.NotContains(t, stdout, "!")
+}
+
+// TestLogPrefix_StateColor 测试前缀颜色使用Supervisor的状态码：FATAL为红色，正常退出的EXITED不是
+func TestLogPrefix_StateColor(t *testing.T) {
+	fatal := logPrefix("api:api", 8, supervisor.ProcessStateFatal, supervisor.LogStdout)
+	exited := logPrefix("cron:cron", 9, supervisor.ProcessStateExited, supervisor.LogStdout)
+	assert.True(t, strings.HasPrefix(fatal, "\x1b[31mapi:api "))
+	assert.True(t, strings.HasPrefix(exited, "\x1b[37mcron:cron "))
+}
+
+// TestParseFlags 测试选项可以出现在位置参数之间
+func TestParseFlags(t *testing.T) {
+	fs := newFlagSet("tail")
+	follow := fs.Bool("f", false, "")
+	length := fs.Int("n", 0, "")
+
+	args, err := parseFlags(fs, []string{"1", "-f", "web:*", "-n", "10", "--", "-x"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "web:*", "-x"}, args)
+	assert.True(t, *follow)
+	assert.Equal(t, 10, *length)
+
+	_, err = parseFlags(newFlagSet("tail"), []string{"--unknown"})
+	assert.Error(t, err)
+}
+
+// TestAddSelectorFlags 测试 --failed 和 -x 转换为进程选择器
+func TestAddSelectorFlags(t *testing.T) {
+	fs := newFlagSet("logs")
+	selectorArgs := addSelectorFlags(fs)
+
+	args, err := parseFlags(fs, []string{"api-*", "-x", "api-worker", "--failed", "--exclude=cron"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"api-*", "--failed", "!api-worker", "!cron"}, selectorArgs(args))
+}
+
+// TestLogView_Render 测试日志行的过滤和格式化
+func TestLogView_Render(t *testing.T) {
+	fs := newFlagSet("logs")
+	view := addLogViewFlags(fs)
+	_, err := parseFlags(fs, []string{"--level", "warn"})
+	assert.NoError(t, err)
+	v, err := view()
+	assert.NoError(t, err)
+
+	_, ok := v.render(`{"level":"info","msg":"ok"}`)
+	assert.False(t, ok)
+	line, ok := v.render(`{"level":"error","msg":"bad"}`)
+	assert.True(t, ok)
+	assert.Contains(t, line, "ERROR")
+	line, ok = v.render("plain text")
+	assert.True(t, ok)
+	assert.Equal(t, "plain text", line)
+	line, ok = v.render(logOverflowNotice)
+	assert.True(t, ok)
+	assert.Equal(t, logOverflowNotice, line)
+
+	v.raw = true
+	line, _ = v.render(`{"level":"error","msg":"bad"}`)
+	assert.Equal(t, `{"level":"error","msg":"bad"}`, line)
+}
+
+// TestAddLogViewFlags_Invalid 测试无效的级别和正则
+func TestAddLogViewFlags_Invalid(t *testing.T) {
+	fs := newFlagSet("logs")
+	view := addLogViewFlags(fs)
+	_, err := parseFlags(fs, []string{"--level", "verbose"})
+	assert.NoError(t, err)
+	_, err = view()
+	assert.Error(t, err)
+
+	fs = newFlagSet("logs")
+	view = addLogViewFlags(fs)
+	_, err = parseFlags(fs, []string{"--grep", "("})
+	assert.NoError(t, err)
+	_, err = view()
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// errNotConfirmed 用户没有确认操作
+var errNotConfirmed = errors.New("操作已取消")
+
+// errConfirmRequired 需要确认但标准输入不是终端
+var errConfirmRequired = errors.New("需要确认，请添加 -y 跳过确认")
+
+// confirm 询问用户是否继续，只有输入 y/yes 时返回true
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Println()
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// stdinIsTerminal 检查标准输入是否为终端，测试中可以替换
+var stdinIsTerminal = func() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirmAction 询问用户是否继续执行，确认时返回nil
+// 标准输入不是终端时不询问，返回 errConfirmRequired；用户拒绝时返回 errNotConfirmed
+func confirmAction(question string) error {
+	if !stdinIsTerminal() {
+		fmt.Println("❌ 标准输入不是终端，无法确认")
+		fmt.Println("💡 提示: 在脚本中使用 -y 跳过确认")
+		return errConfirmRequired
+	}
+	if confirm(question) {
+		return nil
+	}
+	fmt.Println("⏹️ 已取消，未执行任何操作")
+	fmt.Println("💡 提示: 在脚本中使用 -y 跳过确认")
+	return errNotConfirmed
+}
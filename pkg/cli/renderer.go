@@ -1,8 +1,12 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/x1t/sv/pkg/supervisor"
 	"github.com/x1t/sv/pkg/utils"
@@ -16,81 +20,430 @@ func NewCLIRenderer() *CLIRenderer {
 	return &CLIRenderer{}
 }
 
-// ShowStatus 显示Supervisor进程状态
-func (cr *CLIRenderer) ShowStatus(client *supervisor.RPCClient) {
-	processes, err := client.GetAllProcesses()
+// ShowStatus 显示Supervisor进程状态，--wide 时显示启动/停止时间、退出码、启动错误和日志文件
+func (cr *CLIRenderer) ShowStatus(ctx context.Context, client *supervisor.RPCClient, args []string) error {
+	fs := newFlagSet("status")
+	wide := fs.Bool("wide", false, "显示更多列")
+	fs.BoolVar(wide, "w", false, "显示更多列")
+	if rest, err := parseFlags(fs, args); err != nil || len(rest) > 0 {
+		fmt.Println("用法: sv status [-w|--wide]")
+		if err == nil {
+			err = fmt.Errorf("多余的参数: %v", rest)
+		}
+		return err
+	}
+
+	processes, err := client.GetAllProcesses(ctx)
 	if err != nil {
+		if ctx.Err() != nil {
+			fmt.Println("⏹️ 操作已取消")
+			return nil
+		}
 		fmt.Printf("⚠️  获取进程状态失败: %v\n", err)
 		fmt.Println("这是演示模式，显示模拟数据:")
-		processes, _ = client.GetAllProcesses()
+		processes, _ = client.GetAllProcesses(ctx)
+	} else if err := saveIndexSnapshot(client.Host(), processes); err != nil {
+		// 快照只用于后续命令检查序号，保存失败不影响显示
+		fmt.Printf("⚠️  保存进程序号失败: %v\n", err)
 	}
 
 	fmt.Printf("\n🔍 Supervisor进程状态 (共%d个进程)\n", len(processes))
-	utils.DisplayStatus(processes)
+	if *wide {
+		utils.DisplayStatusWide(processes)
+	} else {
+		utils.DisplayStatus(processes)
+	}
 	fmt.Println("\n💡 提示: 使用 'sv start/stop/restart <序号>' 来控制进程")
 	fmt.Println("🔧 配置: 设置SUPERVISOR_HOST环境变量来指定Supervisor地址")
+	return nil
 }
 
-// ControlProcesses 控制多个进程（启动/停止/重启）
-func (cr *CLIRenderer) ControlProcesses(client *supervisor.RPCClient, action string, args []string) {
+// ControlProcesses 控制多个进程（启动/停止/重启），有进程失败时返回错误
+func (cr *CLIRenderer) ControlProcesses(ctx context.Context, client *supervisor.RPCClient, action string, args []string) error {
+	fs := newFlagSet(action)
+	live := fs.Bool("live", false, "按当前进程列表解析序号，不检查序号快照")
+	rolling := fs.Bool("rolling", false, "分批重启，每批保持运行后再重启下一批")
+	batchSize := fs.Int("batch", 1, "滚动重启时每批的进程数")
+	pause := fs.Duration("pause", 5*time.Second, "滚动重启时每批需要保持RUNNING的时间")
+	timeout := fs.Duration("timeout", 60*time.Second, "等待进程停止、以及启动后进入RUNNING的最长时间")
+	parallel := fs.Int("parallel", 0, "同时控制的进程数，0表示通过一次批量请求执行")
+	dryRun := fs.Bool("dry-run", false, "只显示将要执行的操作")
+	yes := fs.Bool("yes", false, "跳过确认")
+	fs.BoolVar(yes, "y", false, "跳过确认")
+	selectorArgs := addSelectorFlags(fs)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		fmt.Printf("❌ 参数错误: %v\n", err)
+		return err
+	}
+	args = selectorArgs(args)
+	var usageErr string
+	switch {
+	case len(args) == 0:
+		usageErr = fmt.Sprintf("未指定进程，用法: sv %s <进程序号|进程名称|范围>", action)
+	case *rolling && action != "restart":
+		usageErr = "--rolling 只能用于 restart"
+	case *rolling && *parallel > 0:
+		usageErr = "--parallel 不能与 --rolling 同时使用，滚动重启请使用 --batch"
+	case *batchSize < 1 || *pause < 0 || *timeout <= 0 || *parallel < 0:
+		usageErr = "--batch 和 --timeout 必须大于0，--pause 和 --parallel 不能为负数"
+	}
+	if usageErr != "" {
+		fmt.Printf("❌ %s\n", usageErr)
+		return errors.New(usageErr)
+	}
+
 	// 首先获取所有进程信息
-	processes, err := client.GetAllProcesses()
+	processes, err := client.GetAllProcesses(ctx)
 	if err != nil {
+		if ctx.Err() != nil {
+			fmt.Println("⏹️ 操作已取消")
+			return ctx.Err()
+		}
 		fmt.Printf("⚠️  获取进程信息失败: %v\n", err)
 		fmt.Println("这是演示模式，将使用模拟数据:")
-		processes, _ = client.GetAllProcesses()
+		processes, _ = client.GetAllProcesses(ctx)
 	}
 
 	// 解析进程名称
+	processNames, err := resolveProcessArgs(client, args, processes, *live)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		rollingBatch := 0
+		if *rolling {
+			rollingBatch = *batchSize
+		}
+		printDryRun(action, processNames, processes, rollingBatch)
+		return nil
+	}
+	// 停止或重启较多进程、或全部进程前先确认，未确认时以非零状态退出
+	if !*yes {
+		if err := confirmSelection(action, processNames, processes); err != nil {
+			return err
+		}
+	}
+
+	// 初始化进程控制器，控制操作与状态查询使用同一个RPC连接
+	ctrl := supervisor.NewProcessController(client)
+	ctrl.SetCommandFallback(commandFallbackEnabled(client))
+	ctrl.Timeout = *timeout
+
+	var counts resultCounts
+	if *rolling {
+		fmt.Printf("🎯 正在滚动重启 %d 个进程 (每批 %d 个，保持运行 %s 后继续)...\n", len(processNames), *batchSize, *pause)
+		opts := supervisor.RollingOptions{BatchSize: *batchSize, Pause: *pause}
+		errs := ctrl.RollingRestart(ctx, processNames, opts, func(batch supervisor.RollingBatch) {
+			fmt.Printf("\n🔁 第 %d/%d 批\n", batch.Number, batch.Total)
+			for i, name := range batch.Names {
+				counts.print(action, name, batch.Errs[i])
+			}
+		})
+		// 批次失败后剩余的进程没有重启
+		var skipped bool
+		for i, name := range processNames {
+			if errors.Is(errs[i], supervisor.ErrRollingAborted) {
+				if !skipped {
+					fmt.Println("\n⛔ 批次失败，停止滚动重启")
+					skipped = true
+				}
+				counts.print(action, name, errs[i])
+			}
+		}
+		counts.printSummary()
+		return counts.err()
+	}
+
+	if *parallel > 0 {
+		fmt.Printf("🎯 正在执行 '%s' 操作 (并发 %d)...\n", action, *parallel)
+		results := newOrderedResults(len(processNames), func(i int, err error) {
+			counts.print(action, processNames[i], err)
+		})
+		ctrl.ControlParallel(ctx, action, processNames, *parallel, results.report)
+		counts.printSummary()
+		return counts.err()
+	}
+
+	fmt.Printf("🎯 正在执行 '%s' 操作...\n", action)
+
+	// 执行控制操作
+	// 所有进程的操作通过一次批量请求完成，再逐个报告结果
+	errs := ctrl.ControlBatch(ctx, action, processNames)
+	for i, name := range processNames {
+		counts.print(action, name, errs[i])
+	}
+	counts.printSummary()
+	return counts.err()
+}
+
+// orderedResults 按进程顺序输出并发执行的结果，先完成的进程等前面的进程都完成后再输出
+type orderedResults struct {
+	done []bool
+	errs []error
+	next int
+	emit func(index int, err error)
+}
+
+// newOrderedResults 创建n个进程的有序输出
+func newOrderedResults(n int, emit func(index int, err error)) *orderedResults {
+	return &orderedResults{done: make([]bool, n), errs: make([]error, n), emit: emit}
+}
+
+// report 记录一个进程的结果，并输出所有已按顺序完成的结果
+func (o *orderedResults) report(index int, err error) {
+	o.done[index], o.errs[index] = true, err
+	for ; o.next < len(o.done) && o.done[o.next]; o.next++ {
+		o.emit(o.next, o.errs[o.next])
+	}
+}
+
+// commandFallbackEnabled 判断RPC不可用时是否回退到supervisorctl
+// 需要通过 SV_SUPERVISORCTL_FALLBACK=1 显式开启；supervisorctl 只能控制本机的Supervisor，因此还要求连接本地
+func commandFallbackEnabled(client *supervisor.RPCClient) bool {
+	if !client.IsLocal() {
+		return false
+	}
+	switch strings.ToLower(os.Getenv("SV_SUPERVISORCTL_FALLBACK")) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// resolveProcessArgs 将进程参数解析为进程名，出错时输出原因
+// 参数中有序号时，除非指定了live，否则要求进程列表与上次 sv status 显示的一致
+func resolveProcessArgs(client *supervisor.RPCClient, args []string, processes []utils.ProcessInfo, live bool) ([]string, error) {
+	if !live {
+		if err := checkIndexSnapshot(client.Host(), args, processes); err != nil {
+			if !errors.Is(err, errIndexSnapshotChanged) {
+				fmt.Printf("❌ 检查进程序号失败: %v\n", err)
+				fmt.Println("💡 提示: 添加 --live 按当前进程列表解析序号")
+			}
+			return nil, err
+		}
+	}
 	processNames, err := utils.ParseProcessIndices(args, processes)
 	if err != nil {
 		fmt.Printf("❌ 解析进程参数失败: %v\n", err)
-		os.Exit(1)
+		return nil, err
 	}
+	return processNames, nil
+}
 
-	fmt.Printf("🎯 正在执行 '%s' 操作...\n", action)
+// SignalProcesses 向进程发送信号，参数为信号名称或编号以及进程参数
+func (cr *CLIRenderer) SignalProcesses(ctx context.Context, client *supervisor.RPCClient, signal string, args []string) error {
+	if _, err := supervisor.NormalizeSignal(signal); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		fmt.Println("💡 提示: 使用信号名称（如 HUP、USR1、TERM）或信号编号")
+		return err
+	}
 
-	// 初始化进程控制器
-	ctrl := supervisor.NewProcessController()
+	fs := newFlagSet("signal")
+	live := fs.Bool("live", false, "按当前进程列表解析序号，不检查序号快照")
+	selectorArgs := addSelectorFlags(fs)
+	args, err := parseFlags(fs, args)
+	if err != nil {
+		fmt.Printf("❌ 参数错误: %v\n", err)
+		return err
+	}
+	args = selectorArgs(args)
+	if len(args) == 0 {
+		fmt.Println("❌ 未指定进程，用法: sv signal <信号> <进程序号|进程名称|范围|group:*|all>")
+		return fmt.Errorf("参数不足")
+	}
 
-	// 执行控制操作
-	var successCount, failCount int
-	for _, name := range processNames {
-		fmt.Printf("  %s 进程 %s ... ", utils.GetActionIcon(action), name)
-		err := ctrl.ControlProcess(action, name)
-		if err != nil {
-			fmt.Printf("❌ 失败 (%v)\n", err)
-			failCount++
+	// all 和 group:* 直接交给Supervisor按组发送，其余参数解析为进程名
+	// 有排除参数时所有参数都需要先解析为进程名
+	var targets, selectors []string
+	passThrough := !utils.HasExclusion(args)
+	for _, arg := range args {
+		if passThrough && (arg == supervisor.SignalAllTarget || supervisor.IsGroupTarget(arg)) {
+			targets = append(targets, arg)
 		} else {
-			fmt.Printf("✅ 成功\n")
-			successCount++
+			selectors = append(selectors, arg)
+		}
+	}
+	if len(selectors) > 0 {
+		processes, err := client.GetAllProcesses(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				fmt.Println("⏹️ 操作已取消")
+				return ctx.Err()
+			}
+			fmt.Printf("❌ 获取进程信息失败: %v\n", err)
+			return err
 		}
+		processNames, err := resolveProcessArgs(client, selectors, processes, *live)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, processNames...)
 	}
 
-	fmt.Printf("\n📊 操作完成: 成功 %d 个，失败 %d 个\n", successCount, failCount)
+	fmt.Printf("🎯 正在发送信号 %s ...\n", strings.ToUpper(signal))
+
+	ctrl := supervisor.NewProcessController(client)
+	ctrl.SetCommandFallback(commandFallbackEnabled(client))
 
-	if failCount > 0 {
+	var counts resultCounts
+	for _, result := range ctrl.SignalProcesses(ctx, signal, targets) {
+		counts.print("signal", result.Name, result.Err)
+	}
+	counts.printSummary()
+	if counts.fail > 0 {
+		return fmt.Errorf("%d 个进程发送信号失败", counts.fail)
+	}
+	return counts.err()
+}
+
+// resultCounts 统计批量操作的结果
+type resultCounts struct {
+	noun                        string // 操作对象的名称，默认为"进程"
+	success, fail, cancel, skip int
+}
+
+// print 输出单个进程的操作结果并计数
+func (c *resultCounts) print(action, name string, err error) {
+	noun := c.noun
+	if noun == "" {
+		noun = "进程"
+	}
+	fmt.Printf("  %s %s %s ... ", utils.GetActionIcon(action), noun, name)
+	switch {
+	case err == nil:
+		fmt.Printf("✅ 成功\n")
+		c.success++
+	case action == "start" && errors.Is(err, supervisor.ErrAlreadyStarted):
+		// 启动已在运行的进程视为成功
+		fmt.Printf("✅ 成功 (已在运行)\n")
+		c.success++
+	case action == "stop" && errors.Is(err, supervisor.ErrNotRunning):
+		// 停止已停止的进程视为成功
+		fmt.Printf("✅ 成功 (未在运行)\n")
+		c.success++
+	case errors.Is(err, supervisor.ErrRollingAborted):
+		fmt.Printf("⏭️ 已跳过\n")
+		c.skip++
+	case errors.Is(err, context.Canceled):
+		// 用户中断时未完成的操作不算失败
+		fmt.Printf("⏹️ 已取消\n")
+		c.cancel++
+	default:
+		fmt.Printf("❌ 失败 (%v)\n", err)
+		var startErr *supervisor.StartError
+		if errors.As(err, &startErr) && len(startErr.StderrTail) > 0 {
+			fmt.Println("     📄 最近的错误输出:")
+			for _, line := range startErr.StderrTail {
+				fmt.Printf("     │ %s\n", line)
+			}
+		}
+		if hint := errorHint(err); hint != "" {
+			fmt.Printf("     💡 %s\n", hint)
+		}
+		c.fail++
+	}
+}
+
+// printSummary 输出成功、失败和取消的数量
+func (c resultCounts) printSummary() {
+	switch {
+	case c.cancel > 0:
+		fmt.Printf("\n📊 操作已中断: 成功 %d 个，失败 %d 个，取消 %d 个\n", c.success, c.fail, c.cancel)
+	case c.skip > 0:
+		fmt.Printf("\n📊 操作已停止: 成功 %d 个，失败 %d 个，跳过 %d 个\n", c.success, c.fail, c.skip)
+	default:
+		fmt.Printf("\n📊 操作完成: 成功 %d 个，失败 %d 个\n", c.success, c.fail)
+	}
+
+	if c.fail > 0 {
 		fmt.Println("💡 提示: 请确保Supervisor正在运行并且配置正确")
 	}
 }
 
+// err 有失败或被中断的操作时返回错误，使命令以非零状态退出
+func (c resultCounts) err() error {
+	switch {
+	case c.fail > 0:
+		return fmt.Errorf("%d 个操作失败", c.fail)
+	case c.cancel > 0:
+		return fmt.Errorf("操作已中断，%d 个未完成", c.cancel)
+	}
+	return nil
+}
+
+// errorHint 根据Supervisor返回的错误类型给出处理建议
+func errorHint(err error) string {
+	switch {
+	case errors.Is(err, supervisor.ErrBadName):
+		return "进程不存在，请使用 'sv status' 查看可用的进程"
+	case errors.Is(err, supervisor.ErrBadSignal):
+		return "信号无效，请使用信号名称（如 HUP、USR1、TERM）或信号编号"
+	case errors.Is(err, supervisor.ErrNoFile):
+		return "程序文件不存在，请检查配置中的command路径"
+	case errors.Is(err, supervisor.ErrNotExecutable):
+		return "程序文件不可执行，请检查文件权限"
+	case errors.Is(err, supervisor.ErrSpawnError):
+		return "进程无法启动，请检查程序日志和配置中的command、directory、user"
+	case errors.Is(err, supervisor.ErrAbnormalTermination):
+		return "进程在启动或停止过程中异常退出，请检查程序日志"
+	case errors.Is(err, supervisor.ErrShutdownState):
+		return "Supervisor正在关闭，请等待其重新启动后再试"
+	case errors.Is(err, supervisor.ErrAlreadyStarted):
+		return "进程已在运行，如需重新加载请使用 'sv restart'"
+	case errors.Is(err, supervisor.ErrNotRunning):
+		return "进程未在运行，请先使用 'sv start' 启动"
+	case errors.Is(err, supervisor.ErrFailed):
+		return "Supervisor未能完成操作，请检查supervisord日志"
+	case errors.Is(err, supervisor.ErrAlreadyAdded):
+		return "进程组已经添加，请运行 'sv reread' 确认配置变更是否已生效"
+	case errors.Is(err, supervisor.ErrStillRunning):
+		return "进程组中仍有进程在运行，请先使用 'sv stop <组名>:*' 停止后再运行 'sv update'"
+	case errors.Is(err, supervisor.ErrCantReread):
+		return "supervisord无法读取配置文件，请检查配置文件语法和include路径"
+	}
+	return ""
+}
+
 // PrintUsage 打印使用说明
 func (cr *CLIRenderer) PrintUsage() {
 	fmt.Println("sv - Supervisor进程管理工具")
 	fmt.Println()
 	fmt.Println("用法:")
 	fmt.Println("  sv status                    # 显示所有进程状态")
+	fmt.Println("  sv status --wide             # 额外显示启动/停止时间、退出码、启动错误和日志文件")
 	fmt.Println("  sv list                     # 显示所有进程状态（同status）")
 	fmt.Println("  sv start <进程>              # 启动进程")
 	fmt.Println("  sv stop <进程>               # 停止进程")
-	fmt.Println("  sv restart <进程>            # 重启进程")
+	fmt.Println("  sv stop <进程> --parallel N  # start/stop/restart 最多同时控制N个进程")
+	fmt.Println("  sv restart <进程>            # 重启进程，等待进程进入RUNNING (--timeout 60s)")
+	fmt.Println("  sv restart <进程> --rolling [--batch N] [--pause 5s]  # 分批滚动重启，每批保持运行后继续")
+	fmt.Println("  sv stop <进程> [--dry-run] [-y]  # 只显示将要操作的进程 / 跳过确认")
+	fmt.Println("  sv show <进程>               # 显示单个进程的运行状态和配置")
+	fmt.Println("  sv info                      # 显示supervisord的状态、版本和配置文件")
+	fmt.Println("  sv supervisord <操作>        # 控制supervisord自身 (restart/reload/shutdown)")
+	fmt.Println("  sv reread                    # 重新读取配置并显示变更")
+	fmt.Println("  sv update [--dry-run] [-y]   # 确认后应用配置变更 (新增/变更/删除进程组)")
+	fmt.Println("  sv signal <信号> <进程>       # 发送信号 (如 HUP、USR1 或编号，支持 group:* 和 all)")
+	fmt.Println("  sv tail <进程> [-f]          # 查看进程日志 (--stderr 标准错误, -n 字节数)")
+	fmt.Println("  sv logs <进程>               # 合并跟踪多个进程的输出和错误日志")
 	fmt.Println("  sv service <action>          # 服务管理")
 	fmt.Println()
 	fmt.Println("进程参数支持:")
 	fmt.Println("  序号      sv restart 1       # 使用序号")
 	fmt.Println("  名称      sv restart myapp   # 使用进程名")
 	fmt.Println("  多个      sv restart 1 3 5   # 多个进程")
-	fmt.Println("  范围      sv restart 1-5     # 序号范围")
+	fmt.Println("  范围      sv restart 1-5     # 序号范围，5- 表示从5到最后一个")
+	fmt.Println("  全部      sv stop all        # 所有进程")
+	fmt.Println("  进程组    sv restart web:*   # 组内所有进程，也可以只写组名 web")
+	fmt.Println("  通配符    sv restart 'api-*' # 匹配完整名称或简写名")
+	fmt.Println("  正则      sv restart '~^api' # ~ 开头的正则表达式")
+	fmt.Println("  状态      sv start state=FATAL  # 按状态选择，--failed 表示FATAL或BACKOFF")
+	fmt.Println("  排除      sv restart all '!3' -x 'web:*'  # !参数 或 -x 参数")
+	fmt.Println()
+	fmt.Println("  序号以上次 sv status 显示的为准，进程列表变化后会拒绝执行；--live 按当前列表解析序号")
+	fmt.Println("  停止或重启超过5个进程、或全部进程时会列出进程并要求确认，未确认时以非零状态退出；脚本中使用 -y")
 	fmt.Println()
 	fmt.Println("服务管理:")
 	fmt.Println("  install   安装sv为系统服务")
@@ -102,8 +455,16 @@ func (cr *CLIRenderer) PrintUsage() {
 	fmt.Println()
 	fmt.Println("环境变量:")
 	fmt.Println("  SUPERVISOR_HOST              # Supervisor RPC地址 (默认: http://localhost:9001/RPC2)")
+	fmt.Println("                               # 也支持Unix套接字: unix:///var/run/supervisor.sock")
 	fmt.Println("  SUPERVISOR_USER              # 用户名 (可选)")
 	fmt.Println("  SUPERVISOR_PASSWORD          # 密码 (可选)")
+	fmt.Println("  SUPERVISOR_CA_FILE           # HTTPS自定义CA证书 (可选)")
+	fmt.Println("  SUPERVISOR_CERT_FILE         # mTLS客户端证书 (可选)")
+	fmt.Println("  SUPERVISOR_KEY_FILE          # mTLS客户端私钥 (可选)")
+	fmt.Println("  SUPERVISOR_SERVER_NAME       # TLS服务器名/SNI (可选)")
+	fmt.Println("  SUPERVISOR_INSECURE_SKIP_VERIFY # 设为1跳过证书校验 (仅测试)")
+	fmt.Println("  SV_SUPERVISORCTL_FALLBACK    # 设为1时，连接本机且RPC不可用时回退到supervisorctl")
+	fmt.Println("  SV_CONFIRM_THRESHOLD         # 停止/重启超过该数量的进程时需要确认 (默认: 5)")
 	fmt.Println()
 	fmt.Println("示例:")
 	fmt.Println("  sv status                    # 查看所有进程状态")
@@ -112,6 +473,9 @@ func (cr *CLIRenderer) PrintUsage() {
 	fmt.Println("  sv stop 2 4 6               # 停止序号2、4、6的进程")
 	fmt.Println("  sv start 1-3                # 启动序号1到3的进程")
 	fmt.Println("  sv restart myapp nginx      # 重启指定名称的进程")
+	fmt.Println("  sv signal HUP 1 3-5 web:*    # 向多个进程和进程组发送SIGHUP")
+	fmt.Println("  sv tail myapp -f             # 持续跟踪myapp的输出")
+	fmt.Println("  sv logs 1-5                  # 合并跟踪序号1到5的进程日志")
 	fmt.Println("  sv service install           # 安装为系统服务")
 	fmt.Println("  sv service start             # 启动系统服务")
 }
\ No newline at end of file
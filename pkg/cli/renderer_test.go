@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/x1t/sv/pkg/supervisor"
+)
+
+// TestOrderedResults 测试并发结果按进程顺序输出
+func TestOrderedResults(t *testing.T) {
+	var emitted []int
+	results := newOrderedResults(4, func(index int, err error) { emitted = append(emitted, index) })
+
+	results.report(2, nil)
+	results.report(1, errors.New("failed"))
+	assert.Empty(t, emitted)
+
+	results.report(0, nil)
+	assert.Equal(t, []int{0, 1, 2}, emitted)
+
+	results.report(3, nil)
+	assert.Equal(t, []int{0, 1, 2, 3}, emitted)
+}
+
+// TestResultCounts_Err 测试部分失败或被中断时返回错误
+func TestResultCounts_Err(t *testing.T) {
+	assert.NoError(t, resultCounts{success: 3}.err())
+	assert.Error(t, resultCounts{success: 2, fail: 1}.err())
+
+	err := resultCounts{success: 1, cancel: 2}.err()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "中断")
+}
+
+// TestCommandFallbackEnabled 测试supervisorctl回退需要显式开启并且连接本地
+func TestCommandFallbackEnabled(t *testing.T) {
+	local := supervisor.NewRPCClient("http://localhost:9001/RPC2", "", "")
+	remote := supervisor.NewRPCClient("http://10.0.0.5:9001/RPC2", "", "")
+
+	t.Setenv("SV_SUPERVISORCTL_FALLBACK", "")
+	assert.False(t, commandFallbackEnabled(local))
+
+	t.Setenv("SV_SUPERVISORCTL_FALLBACK", "1")
+	assert.True(t, commandFallbackEnabled(local))
+	assert.False(t, commandFallbackEnabled(remote))
+}
+
+// TestErrorHint 测试配置更新相关的错误都有处理建议
+func TestErrorHint(t *testing.T) {
+	for _, err := range []error{supervisor.ErrAlreadyAdded, supervisor.ErrStillRunning, supervisor.ErrCantReread} {
+		wrapped := fmt.Errorf("移除进程组失败: %w", err)
+		assert.NotEmpty(t, errorHint(wrapped), err.Error())
+	}
+	assert.Contains(t, errorHint(supervisor.ErrStillRunning), "sv stop")
+	assert.Empty(t, errorHint(errors.New("connection refused")))
+}
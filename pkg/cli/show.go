@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/x1t/sv/pkg/supervisor"
+	"github.com/x1t/sv/pkg/utils"
+)
+
+// programSetting 详情中的一项配置
+type programSetting struct {
+	label string
+	value string
+}
+
+// ShowProcess 显示单个进程的运行状态和配置
+func (cr *CLIRenderer) ShowProcess(ctx context.Context, client *supervisor.RPCClient, args []string) error {
+	if len(args) == 0 {
+		fmt.Println("用法: sv show <进程序号|进程名称>")
+		fmt.Println("示例:")
+		fmt.Println("  sv show 1        # 查看序号为1的进程的详细信息")
+		fmt.Println("  sv show web:web_00")
+		return fmt.Errorf("参数不足")
+	}
+
+	processes, err := client.GetAllProcesses(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			fmt.Println("⏹️ 操作已取消")
+			return nil
+		}
+		fmt.Printf("❌ 获取进程信息失败: %v\n", err)
+		return err
+	}
+	processNames, err := utils.ParseProcessIndices(args, processes)
+	if err != nil {
+		fmt.Printf("❌ 解析进程参数失败: %v\n", err)
+		return err
+	}
+	if len(processNames) != 1 {
+		fmt.Printf("❌ show 只能查看一个进程，当前选中了 %d 个: %v\n", len(processNames), processNames)
+		return fmt.Errorf("选中了多个进程")
+	}
+	name := processNames[0]
+
+	info, err := client.GetProcessInfo(ctx, name)
+	if err != nil {
+		if ctx.Err() != nil {
+			fmt.Println("⏹️ 操作已取消")
+			return nil
+		}
+		fmt.Printf("❌ 获取进程 %s 的信息失败: %v\n", name, err)
+		if hint := errorHint(err); hint != "" {
+			fmt.Printf("     💡 %s\n", hint)
+		}
+		return err
+	}
+	proc := utils.ProcessInfo{
+		Name: info.FullName(), State: info.State, StateName: info.StateName, PID: info.Pid,
+	}
+	for _, p := range processes {
+		if p.Name == proc.Name {
+			proc = p
+		}
+	}
+
+	fmt.Printf("\n📋 进程 %s\n", info.FullName())
+	fmt.Printf("  状态:         %s%s\x1b[0m  %s\n", utils.GetColorByState(info.State), info.StateName, info.Description)
+	fmt.Printf("  PID:          %s\n", pidString(info.Pid))
+	fmt.Printf("  运行时间:     %s\n", proc.Uptime)
+	fmt.Printf("  启动时间:     %s\n", utils.FormatTimestamp(proc.StartTime))
+	fmt.Printf("  停止时间:     %s\n", utils.FormatTimestamp(proc.StopTime))
+	fmt.Printf("  退出码:       %d\n", info.ExitStatus)
+	fmt.Printf("  启动错误:     %s\n", valueOrDash(info.SpawnErr))
+	fmt.Printf("  标准输出日志: %s\n", valueOrDash(info.StdoutLogfile))
+	fmt.Printf("  标准错误日志: %s\n", valueOrDash(info.StderrLogfile))
+
+	// 配置优先使用RPC返回的生效值，RPC没有的字段（user、environment、autorestart）从本机配置文件读取
+	var rpcConfig *supervisor.ConfigInfo
+	if configs, err := client.GetAllConfigInfo(ctx); err == nil {
+		for i := range configs {
+			if configs[i].FullName() == info.FullName() {
+				rpcConfig = &configs[i]
+				break
+			}
+		}
+	}
+	var fileConfig *supervisor.ProgramConfig
+	var fileErr error
+	if client.IsLocal() {
+		cd := supervisor.NewConfigDetector()
+		pid, _ := client.GetPID(ctx)
+		if configPath := cd.ConfigFilePath(pid); configPath != "" {
+			if config, err := cd.FindProgramConfig(configPath, info.Group, info.Name); err == nil {
+				fileConfig = &config
+			} else {
+				fileErr = err
+			}
+		}
+	}
+
+	var sources []string
+	if rpcConfig != nil {
+		sources = append(sources, "RPC")
+	}
+	if fileConfig != nil {
+		sources = append(sources, fmt.Sprintf("%s [%s]", fileConfig.File, fileConfig.Section))
+	}
+	if len(sources) == 0 {
+		fmt.Println("\n⚠️  无法获取进程配置")
+		if fileErr != nil {
+			fmt.Printf("     %v\n", fileErr)
+		} else if !client.IsLocal() {
+			fmt.Println("     💡 远程supervisord不支持getAllConfigInfo时无法读取配置文件")
+		}
+		return nil
+	}
+
+	fmt.Printf("\n⚙️  配置 (来源: %s)\n", joinSources(sources))
+	for _, setting := range programSettings(rpcConfig, fileConfig) {
+		fmt.Printf("  %s %s\n", padLabel(setting.label), setting.value)
+	}
+	return nil
+}
+
+// programSettings 合并RPC返回的配置和配置文件中的原始值
+func programSettings(rpc *supervisor.ConfigInfo, file *supervisor.ProgramConfig) []programSetting {
+	fileValue := func(key string) string {
+		if file == nil {
+			return ""
+		}
+		return file.Values[key]
+	}
+	pick := func(rpcValue func(*supervisor.ConfigInfo) string, key string) string {
+		if rpc != nil {
+			if value := rpcValue(rpc); value != "" {
+				return value
+			}
+		}
+		return valueOrDash(fileValue(key))
+	}
+
+	user := fileValue("user")
+	if user == "" && rpc != nil && rpc.UID > 0 {
+		user = "uid " + strconv.Itoa(rpc.UID)
+	}
+
+	return []programSetting{
+		{"命令", pick(func(c *supervisor.ConfigInfo) string { return c.Command }, "command")},
+		{"目录", pick(func(c *supervisor.ConfigInfo) string { return c.Directory }, "directory")},
+		{"用户", valueOrDash(user)},
+		{"环境变量", valueOrDash(fileValue("environment"))},
+		{"自动启动", pick(func(c *supervisor.ConfigInfo) string { return strconv.FormatBool(c.Autostart) }, "autostart")},
+		{"自动重启", valueOrDash(fileValue("autorestart"))},
+		{"启动等待", pick(func(c *supervisor.ConfigInfo) string { return strconv.Itoa(c.StartSecs) + "秒" }, "startsecs")},
+		{"停止信号", pick(func(c *supervisor.ConfigInfo) string { return supervisor.SignalName(c.StopSignal) }, "stopsignal")},
+		{"优先级", pick(func(c *supervisor.ConfigInfo) string { return strconv.Itoa(c.ProcessPrio) }, "priority")},
+	}
+}
+
+// padLabel 将中文标签补齐到相同的显示宽度
+func padLabel(label string) string {
+	width := 0
+	for _, r := range label {
+		if r > 0x7f {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	padding := ""
+	for i := width; i < 9; i++ {
+		padding += " "
+	}
+	return label + ":" + padding
+}
+
+// joinSources 连接配置来源
+func joinSources(sources []string) string {
+	result := sources[0]
+	for _, source := range sources[1:] {
+		result += " + " + source
+	}
+	return result
+}
+
+// pidString PID为0时显示 "-"
+func pidString(pid int) string {
+	if pid == 0 {
+		return "-"
+	}
+	return strconv.Itoa(pid)
+}
+
+// valueOrDash 空值显示为 "-"
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/x1t/sv/pkg/utils"
+)
+
+// errIndexSnapshotChanged 进程列表与上次显示的不一致
+var errIndexSnapshotChanged = errors.New("进程列表已变化")
+
+// indexSnapshot 上次 sv status 显示的序号与进程名的对应关系
+type indexSnapshot struct {
+	Host    string    `json:"host"`
+	SavedAt time.Time `json:"saved_at"`
+	Names   []string  `json:"names"` // 第i个元素为序号i+1的进程
+}
+
+// snapshotPath 返回当前用户保存某个supervisord序号快照的文件，不同地址分别保存
+func snapshotPath(host string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(host))
+	return filepath.Join(dir, "sv", fmt.Sprintf("index-%x.json", sum[:8])), nil
+}
+
+// saveIndexSnapshot 保存刚显示的进程表的序号
+func saveIndexSnapshot(host string, processes []utils.ProcessInfo) error {
+	path, err := snapshotPath(host)
+	if err != nil {
+		return err
+	}
+	snapshot := indexSnapshot{Host: host, SavedAt: time.Now(), Names: indexNames(processes)}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// 先写临时文件再重命名，避免并发的 sv status 读到不完整的快照
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadIndexSnapshot 读取序号快照，没有快照时ok为false
+func loadIndexSnapshot(host string) (snapshot indexSnapshot, ok bool, err error) {
+	path, err := snapshotPath(host)
+	if err != nil {
+		return snapshot, false, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return snapshot, false, nil
+	}
+	if err != nil {
+		return snapshot, false, err
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, false, fmt.Errorf("序号快照 %s 已损坏: %w", path, err)
+	}
+	return snapshot, true, nil
+}
+
+// checkIndexSnapshot 参数中使用了序号时，确认当前进程列表与上次 sv status 显示的一致
+// 不一致时输出差异并返回错误；没有快照时按当前列表解析
+func checkIndexSnapshot(host string, args []string, processes []utils.ProcessInfo) error {
+	if !utils.UsesIndices(args) {
+		return nil
+	}
+	snapshot, ok, err := loadIndexSnapshot(host)
+	if err != nil || !ok {
+		return err
+	}
+
+	diff := diffIndexSnapshot(snapshot.Names, indexNames(processes))
+	if len(diff) == 0 {
+		return nil
+	}
+	fmt.Printf("❌ 进程列表在上次显示之后 (%s) 发生了变化，序号可能已指向其他进程:\n", snapshot.SavedAt.Format("2006-01-02 15:04:05"))
+	for _, line := range diff {
+		fmt.Println(line)
+	}
+	fmt.Println("💡 提示: 运行 'sv status' 查看最新序号，或添加 --live 按当前进程列表解析序号")
+	return errIndexSnapshotChanged
+}
+
+// diffIndexSnapshot 按序号列出快照与当前列表不同的进程
+func diffIndexSnapshot(snapshot, live []string) []string {
+	var diff []string
+	for i := 0; i < len(snapshot) || i < len(live); i++ {
+		before, after := "(无)", "(无)"
+		if i < len(snapshot) {
+			before = snapshot[i]
+		}
+		if i < len(live) {
+			after = live[i]
+		}
+		if before != after {
+			diff = append(diff, fmt.Sprintf("  %3d  %s → %s", i+1, before, after))
+		}
+	}
+	return diff
+}
+
+// indexNames 按序号顺序返回进程名
+func indexNames(processes []utils.ProcessInfo) []string {
+	names := make([]string, len(processes))
+	for i, p := range processes {
+		names[i] = p.Name
+	}
+	return names
+}
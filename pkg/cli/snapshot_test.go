@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/x1t/sv/pkg/utils"
+)
+
+// TestCheckIndexSnapshot 测试进程列表变化后拒绝按序号操作
+func TestCheckIndexSnapshot(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	host := "http://localhost:9001/RPC2"
+	shown := []utils.ProcessInfo{{Name: "api:api"}, {Name: "web:web_00"}, {Name: "worker:worker"}}
+
+	// 没有快照时按当前列表解析
+	assert.NoError(t, checkIndexSnapshot(host, []string{"3"}, shown))
+
+	require.NoError(t, saveIndexSnapshot(host, shown))
+	assert.NoError(t, checkIndexSnapshot(host, []string{"3"}, shown))
+
+	live := []utils.ProcessInfo{{Name: "api:api"}, {Name: "cron:cron"}, {Name: "web:web_00"}, {Name: "worker:worker"}}
+	assert.ErrorIs(t, checkIndexSnapshot(host, []string{"3"}, live), errIndexSnapshotChanged)
+	assert.ErrorIs(t, checkIndexSnapshot(host, []string{"all", "!2"}, live), errIndexSnapshotChanged)
+	// 只使用进程名时与顺序无关
+	assert.NoError(t, checkIndexSnapshot(host, []string{"worker", "web:*"}, live))
+	// 不同地址的快照互不影响
+	assert.NoError(t, checkIndexSnapshot("unix:///var/run/supervisor.sock", []string{"3"}, live))
+}
+
+// TestDiffIndexSnapshot 测试按序号列出变化
+func TestDiffIndexSnapshot(t *testing.T) {
+	diff := diffIndexSnapshot([]string{"api:api", "web:web_00"}, []string{"api:api", "cron:cron", "web:web_00"})
+	assert.Equal(t, []string{
+		"    2  web:web_00 → cron:cron",
+		"    3  (无) → web:web_00",
+	}, diff)
+	assert.Empty(t, diffIndexSnapshot([]string{"api:api"}, []string{"api:api"}))
+}
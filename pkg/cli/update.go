@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/x1t/sv/pkg/supervisor"
+	"github.com/x1t/sv/pkg/utils"
+)
+
+// UpdateConfig 重新读取配置并显示变更，apply为true时确认后应用（sv update），否则只显示（sv reread）
+func (cr *CLIRenderer) UpdateConfig(ctx context.Context, client *supervisor.RPCClient, apply bool, args []string) error {
+	command := "reread"
+	if apply {
+		command = "update"
+	}
+	fs := newFlagSet(command)
+	dryRun := fs.Bool("dry-run", false, "只显示将要执行的操作")
+	yes := fs.Bool("yes", false, "不询问直接应用")
+	fs.BoolVar(yes, "y", false, "不询问直接应用")
+	if rest, err := parseFlags(fs, args); err != nil || len(rest) > 0 {
+		fmt.Printf("用法: sv %s [--dry-run] [-y|--yes]\n", command)
+		if err == nil {
+			err = fmt.Errorf("多余的参数: %v", rest)
+		}
+		return err
+	}
+
+	fmt.Println("🔍 正在重新读取Supervisor配置...")
+	changes, err := client.ReloadConfig(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			fmt.Println("⏹️ 操作已取消")
+			return ctx.Err()
+		}
+		fmt.Printf("❌ 读取配置失败: %v\n", err)
+		if hint := errorHint(err); hint != "" {
+			fmt.Printf("     💡 %s\n", hint)
+		} else if supervisor.IsFault(err) {
+			fmt.Println("     💡 请检查配置文件语法，或查看supervisord日志")
+		}
+		return err
+	}
+
+	if changes.IsEmpty() {
+		fmt.Println("✅ 配置没有变化")
+		return nil
+	}
+
+	fmt.Printf("\n📝 配置变更: 新增 %d 个，变更 %d 个，删除 %d 个\n",
+		len(changes.Added), len(changes.Changed), len(changes.Removed))
+	utils.DisplayConfigChanges(changes.Added, changes.Changed, changes.Removed)
+
+	if !apply {
+		fmt.Println("\n💡 提示: 使用 'sv update' 应用以上变更")
+		return nil
+	}
+	if *dryRun {
+		fmt.Println("\n🔎 演练模式，未执行任何操作")
+		return nil
+	}
+	if len(changes.Changed)+len(changes.Removed) > 0 {
+		fmt.Println("⚠️  变更和删除的进程组会先被停止")
+	}
+	if !*yes {
+		// 未确认时以非零状态退出，避免脚本误以为变更已应用
+		if err := confirmAction("是否应用以上变更?"); err != nil {
+			return err
+		}
+	}
+
+	ctrl := supervisor.NewProcessController(client)
+	counts := resultCounts{noun: "进程组"}
+	fmt.Println()
+	for _, update := range ctrl.ApplyConfigChanges(ctx, changes) {
+		counts.print(update.Change, update.Group, update.Err)
+	}
+	counts.printSummary()
+	if counts.fail > 0 {
+		return fmt.Errorf("%d 个进程组更新失败", counts.fail)
+	}
+	return counts.err()
+}
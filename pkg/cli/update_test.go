@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/x1t/sv/pkg/supervisor"
+)
+
+// reloadConfigResponse reloadConfig的响应：新增进程组new，删除进程组old
+const reloadConfigResponse = `<?xml version="1.0"?>
+<methodResponse><params><param><value><array><data><value><array><data>
+<value><array><data><value><string>new</string></value></data></array></value>
+<value><array><data></data></array></value>
+<value><array><data><value><string>old</string></value></data></array></value>
+</data></array></value></data></array></value></param></params></methodResponse>`
+
+// TestUpdateConfig_NotTerminal 测试标准输入不是终端并且没有 -y 时不应用变更，并返回错误
+func TestUpdateConfig_NotTerminal(t *testing.T) {
+	isTerminal := stdinIsTerminal
+	t.Cleanup(func() { stdinIsTerminal = isTerminal })
+	stdinIsTerminal = func() bool { return false }
+
+	var mu sync.Mutex
+	var methods []string
+	methodName := regexp.MustCompile(`<methodName>([^<]+)</methodName>`)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		methods = append(methods, methodName.FindStringSubmatch(string(body))[1])
+		mu.Unlock()
+		w.Header().Set("Content-Type", "text/xml")
+		io.WriteString(w, reloadConfigResponse)
+	}))
+	t.Cleanup(server.Close)
+	client := supervisor.NewRPCClient(server.URL+"/RPC2", "", "")
+
+	err := NewCLIRenderer().UpdateConfig(context.Background(), client, true, nil)
+	assert.ErrorIs(t, err, errConfirmRequired)
+	assert.Equal(t, []string{"supervisor.reloadConfig"}, methods)
+
+	// 演练模式不需要确认
+	err = NewCLIRenderer().UpdateConfig(context.Background(), client, true, []string{"--dry-run"})
+	assert.NoError(t, err)
+}
@@ -1,9 +1,9 @@
 package supervisor
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 )
 
@@ -15,20 +15,20 @@ func NewConfigDetector() *ConfigDetector {
 	return &ConfigDetector{}
 }
 
+// supervisorConfigPaths 默认的supervisor配置文件位置
+var supervisorConfigPaths = []string{
+	"/etc/supervisor/supervisord.conf",
+	"/etc/supervisor/conf.d/*.conf",
+	"/etc/supervisord.conf",
+}
+
 // DetectAndEnableRPC 检测并开启Supervisor RPC功能
 func (cd *ConfigDetector) DetectAndEnableRPC() error {
-	// 检查默认的supervisor配置文件位置
-	configPaths := []string{
-		"/etc/supervisor/supervisord.conf",
-		"/etc/supervisor/conf.d/*.conf",
-		"/etc/supervisord.conf",
-	}
-
 	// 标记是否修改了配置文件
 	configModified := false
 
 	// 简单地检查和修改第一个存在的配置文件
-	for _, configPath := range configPaths {
+	for _, configPath := range supervisorConfigPaths {
 		// 简化处理：只处理主配置文件，不处理通配符路径
 		if strings.Contains(configPath, "*") {
 			continue
@@ -42,6 +42,11 @@ func (cd *ConfigDetector) DetectAndEnableRPC() error {
 				continue
 			}
 
+			// 已启用unix_http_server时通过套接字通信，无需开放TCP端口
+			if !enabled && !cd.needsInetHTTPServer(configPath) {
+				enabled = true
+			}
+
 			if !enabled {
 				// 如果没有启用inet_http_server，则添加配置
 				fmt.Printf("🔧 未发现inet_http_server配置，正在添加...\n")
@@ -95,19 +100,18 @@ func (cd *ConfigDetector) DetectAndEnableRPC() error {
 	return fmt.Errorf("未找到supervisor配置文件")
 }
 
-// RestartSupervisor 尝试重启Supervisor服务
+// RestartSupervisor 通过系统服务管理器重启Supervisor服务
+//
+// 修改inet_http_server等配置后RPC可能尚不可用，因此这里直接使用systemctl/service，
+// 服务名按本机实际安装的supervisor或supervisord检测。
 func (cd *ConfigDetector) RestartSupervisor() error {
-	// 尝试使用systemctl重启supervisor (在大多数Linux系统上)
-	cmd := exec.Command("systemctl", "restart", "supervisor")
-	if err := cmd.Run(); err != nil {
-		fmt.Printf("systemctl restart supervisor 失败: %v\n", err)
-		// 如果systemctl失败，尝试使用service命令
-		cmd = exec.Command("service", "supervisor", "restart")
-		if err := cmd.Run(); err != nil {
-			fmt.Printf("service restart supervisor 失败: %v\n", err)
-			// 如果还是失败，返回错误而不是继续尝试
-			return fmt.Errorf("无法重启supervisor服务: systemctl和service命令都失败了")
-		}
+	ctx := context.Background()
+	service, err := detectInitService(ctx)
+	if err != nil {
+		return fmt.Errorf("无法重启supervisor服务: %w", err)
+	}
+	if err := service.run(ctx, "restart"); err != nil {
+		return fmt.Errorf("无法重启supervisor服务: %w", err)
 	}
 	return nil
 }
@@ -277,13 +281,124 @@ supervisor.rpcinterface_factory = supervisor.rpcinterface:make_main_rpcinterface
 	return os.WriteFile(configPath, []byte(newContent), info.Mode())
 }
 
+// needsInetHTTPServer 判断是否需要通过inet_http_server(TCP)连接本机Supervisor
+func (cd *ConfigDetector) needsInetHTTPServer(configPath string) bool {
+	// 显式指定了HTTP地址时必须使用TCP
+	if h := os.Getenv("SUPERVISOR_HOST"); h != "" {
+		return !strings.HasPrefix(h, unixSocketScheme)
+	}
+	socketPath, err := cd.UnixSocketPath(configPath)
+	return err != nil || socketPath == ""
+}
+
+// UnixSocketPath 获取配置文件中[unix_http_server]的套接字路径，未配置时返回空字符串
+func (cd *ConfigDetector) UnixSocketPath(configPath string) (string, error) {
+	section, err := readConfigSection(configPath, "unix_http_server")
+	if err != nil {
+		return "", err
+	}
+	return section["file"], nil
+}
+
+// findUnixSocket 在默认配置文件中查找已存在的Unix域套接字
+func (cd *ConfigDetector) findUnixSocket() string {
+	for _, configPath := range supervisorConfigPaths {
+		if strings.Contains(configPath, "*") {
+			continue
+		}
+		socketPath, err := cd.UnixSocketPath(configPath)
+		if err != nil || socketPath == "" {
+			continue
+		}
+		if info, err := os.Stat(socketPath); err == nil && info.Mode()&os.ModeSocket != 0 {
+			return socketPath
+		}
+	}
+	return ""
+}
+
+// readConfigSection 读取ini配置文件中指定段的键值，段不存在时返回空map
+func readConfigSection(configPath, name string) (map[string]string, error) {
+	sections, err := readConfigSections(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if values, ok := sections[name]; ok {
+		return values, nil
+	}
+	return make(map[string]string), nil
+}
+
+// readConfigSections 读取ini配置文件中所有段的键值
+//
+// 以空白开头的行是上一个值的续行（如多行的environment），与supervisord的解析方式一致。
+func readConfigSections(configPath string) (map[string]map[string]string, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make(map[string]map[string]string)
+	var values map[string]string
+	lastKey := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// 检查段开始
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			name := strings.TrimSpace(strings.Trim(trimmed, "[]"))
+			if sections[name] == nil {
+				sections[name] = make(map[string]string)
+			}
+			values = sections[name]
+			lastKey = ""
+			continue
+		}
+		if values == nil {
+			continue
+		}
+
+		// 续行追加到上一个值
+		if (line[0] == ' ' || line[0] == '\t') && lastKey != "" {
+			values[lastKey] = strings.TrimSpace(values[lastKey] + " " + stripInlineComment(trimmed))
+			continue
+		}
+
+		key, value, found := strings.Cut(trimmed, "=")
+		if !found {
+			continue
+		}
+		lastKey = strings.TrimSpace(key)
+		values[lastKey] = strings.TrimSpace(stripInlineComment(value))
+	}
+	return sections, nil
+}
+
+// stripInlineComment 去掉行内注释，例如 "file=/var/run/supervisor.sock   ; (the path to the socket file)"
+func stripInlineComment(value string) string {
+	if idx := strings.Index(value, " ;"); idx != -1 {
+		value = value[:idx]
+	}
+	if idx := strings.Index(value, "\t;"); idx != -1 {
+		value = value[:idx]
+	}
+	return value
+}
+
 // ReadSupervisorConfig 读取supervisor配置获取连接信息
 func (cd *ConfigDetector) ReadSupervisorConfig() (host, username, password string) {
-	// 默认值
+	// 默认值：优先使用本机已存在的Unix域套接字
 	host = "http://localhost:9001/RPC2"
 	username = ""
 	password = ""
 
+	if socketPath := cd.findUnixSocket(); socketPath != "" {
+		host = unixSocketScheme + socketPath
+	}
+
 	// 尝试从环境变量读取
 	if h := os.Getenv("SUPERVISOR_HOST"); h != "" {
 		host = h
@@ -297,4 +412,19 @@ func (cd *ConfigDetector) ReadSupervisorConfig() (host, username, password strin
 
 	// 也可以从配置文件读取，这里简化处理
 	return
-}
\ No newline at end of file
+}
+
+// ReadTLSOptions 从环境变量读取HTTPS连接的TLS选项
+func (cd *ConfigDetector) ReadTLSOptions() TLSOptions {
+	opts := TLSOptions{
+		CAFile:     os.Getenv("SUPERVISOR_CA_FILE"),
+		CertFile:   os.Getenv("SUPERVISOR_CERT_FILE"),
+		KeyFile:    os.Getenv("SUPERVISOR_KEY_FILE"),
+		ServerName: os.Getenv("SUPERVISOR_SERVER_NAME"),
+	}
+	switch strings.ToLower(os.Getenv("SUPERVISOR_INSECURE_SKIP_VERIFY")) {
+	case "1", "true", "yes":
+		opts.InsecureSkipVerify = true
+	}
+	return opts
+}
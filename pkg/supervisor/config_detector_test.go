@@ -0,0 +1,44 @@
+package supervisor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestUnixSocketPath 测试读取[unix_http_server]中的套接字路径
+func TestUnixSocketPath(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "supervisord.conf")
+	content := `[unix_http_server]
+file=/var/run/supervisor.sock   ; (the path to the socket file)
+chmod=0700                       ; sockef file mode (default 0700)
+
+[supervisord]
+logfile=/var/log/supervisor/supervisord.log
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	cd := NewConfigDetector()
+	socketPath, err := cd.UnixSocketPath(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "/var/run/supervisor.sock", socketPath)
+
+	t.Setenv("SUPERVISOR_HOST", "")
+	assert.False(t, cd.needsInetHTTPServer(configPath))
+
+	t.Setenv("SUPERVISOR_HOST", "http://localhost:9001/RPC2")
+	assert.True(t, cd.needsInetHTTPServer(configPath))
+}
+
+// TestUnixSocketPath_Missing 测试未配置unix_http_server
+func TestUnixSocketPath_Missing(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "supervisord.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte(";[unix_http_server]\n;file=/tmp/x.sock\n"), 0644))
+
+	socketPath, err := NewConfigDetector().UnixSocketPath(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, "", socketPath)
+}
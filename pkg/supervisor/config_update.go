@@ -0,0 +1,100 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// 进程组的配置变更类型
+const (
+	GroupAdded   = "added"
+	GroupChanged = "changed"
+	GroupRemoved = "removed"
+)
+
+// IsEmpty 检查配置是否没有变化
+func (c ConfigChanges) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Changed) == 0 && len(c.Removed) == 0
+}
+
+// GroupUpdate 配置更新中一个进程组的操作结果
+type GroupUpdate struct {
+	Group  string
+	Change string // GroupAdded、GroupChanged 或 GroupRemoved
+	Err    error
+}
+
+// ApplyConfigChanges 按 supervisorctl update 的方式应用 reloadConfig 返回的变更
+//
+// 删除的组先停止再移除；变更的组停止、移除后重新添加；新增的组直接添加。
+// 某个组停止失败时不会移除它，其他组继续处理。
+func (pc *ProcessController) ApplyConfigChanges(ctx context.Context, changes ConfigChanges) []GroupUpdate {
+	var updates []GroupUpdate
+	for _, group := range changes.Removed {
+		updates = append(updates, GroupUpdate{Group: group, Change: GroupRemoved, Err: pc.removeGroup(ctx, group)})
+	}
+	for _, group := range changes.Changed {
+		err := pc.removeGroup(ctx, group)
+		if err == nil {
+			err = pc.addGroup(ctx, group)
+		}
+		updates = append(updates, GroupUpdate{Group: group, Change: GroupChanged, Err: err})
+	}
+	for _, group := range changes.Added {
+		updates = append(updates, GroupUpdate{Group: group, Change: GroupAdded, Err: pc.addGroup(ctx, group)})
+	}
+	return updates
+}
+
+// removeGroup 停止进程组中的所有进程并移除该组
+//
+// 停止请求不等待进程退出，之后轮询直到组内进程都已停止，最长等待Timeout。
+func (pc *ProcessController) removeGroup(ctx context.Context, group string) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("移除进程组失败: %w", ctx.Err())
+	}
+
+	statuses, err := pc.client.StopProcessGroup(ctx, group, false)
+	if err != nil && !errors.Is(err, ErrBadName) {
+		return fmt.Errorf("停止进程组失败: %w", err)
+	}
+	var names []string
+	for _, status := range statuses {
+		err := status.Err()
+		if err != nil && !errors.Is(err, ErrNotRunning) {
+			return fmt.Errorf("停止进程 %s 失败: %w", status.FullName(), err)
+		}
+		if err == nil {
+			names = append(names, status.FullName())
+		}
+	}
+
+	indices := make([]int, len(names))
+	for i := range names {
+		indices[i] = i
+	}
+	errs := make([]error, len(names))
+	pc.waitForProcesses(ctx, names, indices, errs, "停止", processStopped)
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("停止进程 %s 失败: %w", names[i], err)
+		}
+	}
+
+	if err := pc.client.RemoveProcessGroup(ctx, group); err != nil {
+		return fmt.Errorf("移除进程组失败: %w", err)
+	}
+	return nil
+}
+
+// addGroup 添加进程组，supervisord会按autostart启动其中的进程
+func (pc *ProcessController) addGroup(ctx context.Context, group string) error {
+	if ctx.Err() != nil {
+		return fmt.Errorf("添加进程组失败: %w", ctx.Err())
+	}
+	if err := pc.client.AddProcessGroup(ctx, group); err != nil {
+		return fmt.Errorf("添加进程组失败: %w", err)
+	}
+	return nil
+}
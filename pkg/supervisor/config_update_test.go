@@ -0,0 +1,91 @@
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestApplyConfigChanges 测试按 supervisorctl update 的顺序应用变更
+func TestApplyConfigChanges(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	var ops []string
+	record := func(op string) fakeHandler {
+		return func(params []interface{}) (interface{}, error) {
+			ops = append(ops, op+" "+params[0].(string))
+			if op == "stop" {
+				return []interface{}{
+					map[string]interface{}{"name": "a", "group": params[0], "status": FaultNotRunning, "description": "NOT_RUNNING"},
+				}, nil
+			}
+			return true, nil
+		}
+	}
+	fs.handle("supervisor.stopProcessGroup", record("stop"))
+	fs.handle("supervisor.removeProcessGroup", record("remove"))
+	fs.handle("supervisor.addProcessGroup", record("add"))
+
+	updates := NewProcessController(client).ApplyConfigChanges(context.Background(), ConfigChanges{
+		Added:   []string{"new"},
+		Changed: []string{"web"},
+		Removed: []string{"old"},
+	})
+
+	assert.Equal(t, []GroupUpdate{
+		{Group: "old", Change: GroupRemoved},
+		{Group: "web", Change: GroupChanged},
+		{Group: "new", Change: GroupAdded},
+	}, updates)
+	assert.Equal(t, []string{"stop old", "remove old", "stop web", "remove web", "add web", "add new"}, ops)
+}
+
+// TestApplyConfigChanges_StopFailed 测试停止失败时不移除进程组
+func TestApplyConfigChanges_StopFailed(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.stopProcessGroup", []interface{}{
+		map[string]interface{}{"name": "a", "group": "web", "status": FaultFailed, "description": "FAILED"},
+	})
+	fs.result("supervisor.addProcessGroup", true)
+
+	updates := NewProcessController(client).ApplyConfigChanges(context.Background(), ConfigChanges{
+		Added:   []string{"new"},
+		Changed: []string{"web"},
+	})
+
+	require.Len(t, updates, 2)
+	assert.ErrorIs(t, updates[0].Err, ErrFailed)
+	assert.Contains(t, updates[0].Err.Error(), "web:a")
+	assert.NoError(t, updates[1].Err)
+	assert.NotContains(t, fs.methods(), "supervisor.removeProcessGroup")
+}
+
+// TestApplyConfigChanges_SlowStop 测试进程停止耗时超过单次RPC调用的超时时仍能移除进程组
+func TestApplyConfigChanges_SlowStop(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	client.SetTimeout(100 * time.Millisecond)
+	table := newFakeProcessTable(fs, "web:web_00", "web:web_01", "web:web_02")
+	table.stopDelay = 300 * time.Millisecond
+	fs.handle("supervisor.removeProcessGroup", func(params []interface{}) (interface{}, error) {
+		for _, p := range table.processes {
+			if p.state != ProcessStateStopped {
+				return nil, &Fault{Code: FaultStillRunning, String: "STILL_RUNNING: " + p.name}
+			}
+		}
+		return true, nil
+	})
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = 10 * time.Millisecond
+	updates := ctrl.ApplyConfigChanges(context.Background(), ConfigChanges{Removed: []string{"web"}})
+	require.Len(t, updates, 1)
+	assert.NoError(t, updates[0].Err)
+}
+
+// TestConfigChanges_IsEmpty 测试空变更判断
+func TestConfigChanges_IsEmpty(t *testing.T) {
+	assert.True(t, ConfigChanges{}.IsEmpty())
+	assert.False(t, ConfigChanges{Removed: []string{"a"}}.IsEmpty())
+}
@@ -0,0 +1,213 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// supervisord自身的控制操作
+const (
+	DaemonRestart  = "restart"
+	DaemonReload   = "reload"
+	DaemonShutdown = "shutdown"
+)
+
+// supervisorUnitNames supervisord常见的系统服务名：Debian/Ubuntu为supervisor，RHEL/CentOS为supervisord
+var supervisorUnitNames = []string{"supervisor", "supervisord"}
+
+// runCommand 执行外部命令并返回合并后的输出，测试时可以替换
+var runCommand = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
+
+// initService 管理supervisord的系统服务
+type initService struct {
+	manager string // systemctl 或 service
+	unit    string
+}
+
+// String 返回服务的描述，例如 "systemctl supervisord"
+func (s initService) String() string {
+	return s.manager + " " + s.unit
+}
+
+// detectInitService 检测本机supervisord实际使用的系统服务名
+func detectInitService(ctx context.Context) (initService, error) {
+	for _, unit := range supervisorUnitNames {
+		output, err := runCommand(ctx, "systemctl", "show", "--property=LoadState", "--value", unit+".service")
+		if err == nil && strings.TrimSpace(string(output)) == "loaded" {
+			return initService{manager: "systemctl", unit: unit}, nil
+		}
+	}
+	for _, unit := range supervisorUnitNames {
+		if _, err := os.Stat("/etc/init.d/" + unit); err == nil {
+			return initService{manager: "service", unit: unit}, nil
+		}
+	}
+	return initService{}, fmt.Errorf("未找到supervisord的系统服务 (已尝试: %s)", strings.Join(supervisorUnitNames, ", "))
+}
+
+// run 通过系统服务管理器执行操作（restart/reload/stop）
+func (s initService) run(ctx context.Context, action string) error {
+	var output []byte
+	var err error
+	if s.manager == "systemctl" {
+		output, err = runCommand(ctx, "systemctl", action, s.unit)
+	} else {
+		output, err = runCommand(ctx, "service", s.unit, action)
+	}
+	if err != nil {
+		return fmt.Errorf("%s %s 失败: %v, 输出: %s", s, action, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// DaemonResult supervisord控制操作的结果
+type DaemonResult struct {
+	Via   string          // 执行方式：TransportRPC 或系统服务，例如 "systemctl supervisord"
+	State SupervisorState // 操作完成后的状态，关闭时为零值
+}
+
+// DaemonController 控制supervisord自身的重启、重新加载和关闭
+type DaemonController struct {
+	client       *RPCClient
+	initFallback bool
+	PollInterval time.Duration // 轮询getState的间隔
+	Timeout      time.Duration // 等待supervisord恢复或退出的最长时间
+	RestartGrace time.Duration // 重启后一直未观察到非RUNNING状态时，视为已完成重启的时间
+}
+
+// NewDaemonController 创建supervisord控制器
+func NewDaemonController(client *RPCClient) *DaemonController {
+	return &DaemonController{
+		client:       client,
+		PollInterval: 500 * time.Millisecond,
+		Timeout:      60 * time.Second,
+		RestartGrace: 3 * time.Second,
+	}
+}
+
+// SetInitFallback 设置RPC不可用时是否回退到本机的系统服务管理器
+func (dc *DaemonController) SetInitFallback(enabled bool) {
+	dc.initFallback = enabled
+}
+
+// Run 执行 DaemonRestart、DaemonReload 或 DaemonShutdown
+//
+// 优先通过 supervisor.restart/supervisor.shutdown 执行，然后轮询getState，
+// 直到supervisord恢复RUNNING（重启/重新加载）或不再响应（关闭）。
+func (dc *DaemonController) Run(ctx context.Context, action string) (DaemonResult, error) {
+	var rpcErr error
+	switch action {
+	case DaemonRestart, DaemonReload:
+		rpcErr = dc.client.Restart(ctx)
+	case DaemonShutdown:
+		rpcErr = dc.client.Shutdown(ctx)
+	default:
+		return DaemonResult{}, fmt.Errorf("不支持的操作: %s", action)
+	}
+
+	if rpcErr == nil {
+		result := DaemonResult{Via: TransportRPC}
+		var err error
+		if action == DaemonShutdown {
+			err = dc.waitForShutdown(ctx)
+		} else {
+			result.State, err = dc.waitForRunning(ctx, true)
+		}
+		return result, err
+	}
+
+	// supervisord返回了fault（例如正在关闭）说明RPC可用，不应回退
+	if IsFault(rpcErr) || !dc.initFallback || ctx.Err() != nil {
+		return DaemonResult{}, fmt.Errorf("%s supervisord失败: %w", action, rpcErr)
+	}
+
+	service, err := detectInitService(ctx)
+	if err != nil {
+		return DaemonResult{}, fmt.Errorf("%s supervisord失败: %w (%v)", action, rpcErr, err)
+	}
+	result := DaemonResult{Via: service.String()}
+	if err := service.run(ctx, initAction(action)); err != nil {
+		return result, fmt.Errorf("%s supervisord失败: %w", action, err)
+	}
+	if action == DaemonShutdown {
+		return result, nil
+	}
+	// 系统服务命令是同步的，直接等待RPC恢复
+	result.State, err = dc.waitForRunning(ctx, false)
+	return result, err
+}
+
+// initAction 将supervisord操作转换为系统服务管理器的操作
+func initAction(action string) string {
+	if action == DaemonShutdown {
+		return "stop"
+	}
+	return action
+}
+
+// waitForRunning 轮询getState直到supervisord恢复RUNNING
+//
+// expectDown为true时，需要先观察到非RUNNING状态或连接失败，避免把重启前的状态当作已恢复；
+// 重启过快时，超过RestartGrace仍一直为RUNNING也视为已完成。
+func (dc *DaemonController) waitForRunning(ctx context.Context, expectDown bool) (SupervisorState, error) {
+	ctx, cancel := context.WithTimeout(ctx, dc.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	sawDown := !expectDown
+	var lastErr error
+	var state SupervisorState
+	for {
+		var err error
+		state, err = dc.client.GetState(ctx)
+		switch {
+		case err != nil:
+			lastErr = err
+			sawDown = true
+		case state.Code != SupervisorStateRunning:
+			sawDown = true
+			if state.Code == SupervisorStateFatal {
+				return state, fmt.Errorf("supervisord进入%s状态", state.Name)
+			}
+		case sawDown || time.Since(start) >= dc.RestartGrace:
+			return state, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil && !errors.Is(lastErr, ctx.Err()) {
+				return state, fmt.Errorf("等待supervisord恢复超时: %w (最后一次错误: %v)", ctx.Err(), lastErr)
+			}
+			return state, fmt.Errorf("等待supervisord恢复超时: %w", ctx.Err())
+		case <-time.After(dc.PollInterval):
+		}
+	}
+}
+
+// waitForShutdown 轮询getState直到supervisord不再响应
+func (dc *DaemonController) waitForShutdown(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, dc.Timeout)
+	defer cancel()
+
+	for {
+		if _, err := dc.client.GetState(ctx); err != nil && !IsFault(err) {
+			if ctx.Err() != nil {
+				return fmt.Errorf("等待supervisord退出超时: %w", ctx.Err())
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("等待supervisord退出超时: %w", ctx.Err())
+		case <-time.After(dc.PollInterval):
+		}
+	}
+}
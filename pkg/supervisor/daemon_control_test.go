@@ -0,0 +1,129 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCommands 替换runCommand，记录执行的命令并按outputs返回输出
+func stubCommands(t *testing.T, outputs map[string]string) *[]string {
+	var commands []string
+	original := runCommand
+	runCommand = func(_ context.Context, name string, args ...string) ([]byte, error) {
+		command := strings.Join(append([]string{name}, args...), " ")
+		commands = append(commands, command)
+		if output, ok := outputs[command]; ok {
+			return []byte(output), nil
+		}
+		return nil, fmt.Errorf("exit status 1")
+	}
+	t.Cleanup(func() { runCommand = original })
+	return &commands
+}
+
+// newTestDaemonController 创建轮询间隔较短的控制器
+func newTestDaemonController(client *RPCClient) *DaemonController {
+	dc := NewDaemonController(client)
+	dc.PollInterval = time.Millisecond
+	dc.Timeout = time.Second
+	return dc
+}
+
+// TestDaemonController_RestartViaRPC 测试通过RPC重启并等待恢复RUNNING
+func TestDaemonController_RestartViaRPC(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.restart", true)
+	states := []int{SupervisorStateRunning, SupervisorStateRestarting, SupervisorStateRunning}
+	polls := 0
+	fs.handle("supervisor.getState", func([]interface{}) (interface{}, error) {
+		code := states[min(polls, len(states)-1)]
+		polls++
+		name := map[int]string{SupervisorStateRunning: "RUNNING", SupervisorStateRestarting: "RESTARTING"}[code]
+		return map[string]interface{}{"statecode": code, "statename": name}, nil
+	})
+
+	dc := newTestDaemonController(client)
+	dc.RestartGrace = time.Hour
+	result, err := dc.Run(context.Background(), DaemonReload)
+	require.NoError(t, err)
+	assert.Equal(t, TransportRPC, result.Via)
+	assert.Equal(t, "RUNNING", result.State.Name)
+	assert.Equal(t, 3, polls, "必须先观察到非RUNNING状态")
+}
+
+// TestDaemonController_RestartGrace 测试重启过快时超过宽限时间视为完成
+func TestDaemonController_RestartGrace(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.restart", true)
+	fs.result("supervisor.getState", map[string]interface{}{"statecode": 1, "statename": "RUNNING"})
+
+	dc := newTestDaemonController(client)
+	dc.RestartGrace = 0
+	result, err := dc.Run(context.Background(), DaemonRestart)
+	require.NoError(t, err)
+	assert.Equal(t, SupervisorStateRunning, result.State.Code)
+}
+
+// TestDaemonController_FaultDoesNotFallback 测试RPC返回fault时不回退到系统服务
+func TestDaemonController_FaultDoesNotFallback(t *testing.T) {
+	commands := stubCommands(t, nil)
+	fs, client := newFakeSupervisor(t)
+	fs.handle("supervisor.shutdown", func([]interface{}) (interface{}, error) {
+		return nil, &Fault{Code: FaultShutdownState, String: "SHUTDOWN_STATE"}
+	})
+
+	dc := newTestDaemonController(client)
+	dc.SetInitFallback(true)
+	_, err := dc.Run(context.Background(), DaemonShutdown)
+	assert.ErrorIs(t, err, ErrShutdownState)
+	assert.Empty(t, *commands)
+}
+
+// TestDaemonController_InitFallback 测试RPC不可用时按检测到的服务名回退
+func TestDaemonController_InitFallback(t *testing.T) {
+	commands := stubCommands(t, map[string]string{
+		"systemctl show --property=LoadState --value supervisor.service":  "not-found\n",
+		"systemctl show --property=LoadState --value supervisord.service": "loaded\n",
+		"systemctl stop supervisord":                                      "",
+	})
+	client := NewRPCClient("http://127.0.0.1:1/RPC2", "", "")
+
+	dc := newTestDaemonController(client)
+	dc.SetInitFallback(true)
+	result, err := dc.Run(context.Background(), DaemonShutdown)
+	require.NoError(t, err)
+	assert.Equal(t, "systemctl supervisord", result.Via)
+	assert.Equal(t, "systemctl stop supervisord", (*commands)[len(*commands)-1])
+}
+
+// TestDaemonController_NoFallbackForRemote 测试未开启回退时直接返回RPC错误
+func TestDaemonController_NoFallbackForRemote(t *testing.T) {
+	commands := stubCommands(t, nil)
+	client := NewRPCClient("http://127.0.0.1:1/RPC2", "", "")
+
+	_, err := newTestDaemonController(client).Run(context.Background(), DaemonRestart)
+	assert.Error(t, err)
+	assert.Empty(t, *commands)
+}
+
+// TestDaemonController_WaitForShutdown 测试supervisord不再响应时视为已关闭
+func TestDaemonController_WaitForShutdown(t *testing.T) {
+	client := NewRPCClient("http://127.0.0.1:1/RPC2", "", "")
+	assert.NoError(t, newTestDaemonController(client).waitForShutdown(context.Background()))
+}
+
+// TestDetectInitService 测试系统服务名检测
+func TestDetectInitService(t *testing.T) {
+	stubCommands(t, map[string]string{
+		"systemctl show --property=LoadState --value supervisor.service": "loaded\n",
+	})
+	service, err := detectInitService(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, initService{manager: "systemctl", unit: "supervisor"}, service)
+}
@@ -0,0 +1,79 @@
+package supervisor
+
+import "errors"
+
+// Supervisor XML-RPC fault代码（见 supervisor/xmlrpc.py 中的 Faults）
+const (
+	FaultUnknownMethod        = 1
+	FaultIncorrectParameters  = 2
+	FaultBadArguments         = 3
+	FaultSignatureUnsupported = 4
+	FaultShutdownState        = 6
+	FaultBadName              = 10
+	FaultBadSignal            = 11
+	FaultNoFile               = 20
+	FaultNotExecutable        = 21
+	FaultFailed               = 30
+	FaultAbnormalTermination  = 40
+	FaultSpawnError           = 50
+	FaultAlreadyStarted       = 60
+	FaultNotRunning           = 70
+	FaultSuccess              = 80
+	FaultAlreadyAdded         = 90
+	FaultStillRunning         = 91
+	FaultCantReread           = 92
+)
+
+// 与fault代码对应的错误值，可通过 errors.Is 判断 *Fault 的类型
+var (
+	ErrUnknownMethod        = errors.New("未知的RPC方法")
+	ErrIncorrectParameters  = errors.New("RPC参数数量错误")
+	ErrBadArguments         = errors.New("RPC参数无效")
+	ErrSignatureUnsupported = errors.New("不支持的方法签名")
+	ErrShutdownState        = errors.New("Supervisor正在关闭")
+	ErrBadName              = errors.New("进程或进程组不存在")
+	ErrBadSignal            = errors.New("无效的信号")
+	ErrNoFile               = errors.New("程序文件不存在")
+	ErrNotExecutable        = errors.New("程序文件不可执行")
+	ErrFailed               = errors.New("操作失败")
+	ErrAbnormalTermination  = errors.New("进程异常退出")
+	ErrSpawnError           = errors.New("进程启动失败")
+	ErrAlreadyStarted       = errors.New("进程已在运行")
+	ErrNotRunning           = errors.New("进程未运行")
+	ErrAlreadyAdded         = errors.New("进程组已存在")
+	ErrStillRunning         = errors.New("进程组仍在运行")
+	ErrCantReread           = errors.New("无法重新读取配置")
+)
+
+// faultErrors fault代码到错误值的映射
+var faultErrors = map[int]error{
+	FaultUnknownMethod:        ErrUnknownMethod,
+	FaultIncorrectParameters:  ErrIncorrectParameters,
+	FaultBadArguments:         ErrBadArguments,
+	FaultSignatureUnsupported: ErrSignatureUnsupported,
+	FaultShutdownState:        ErrShutdownState,
+	FaultBadName:              ErrBadName,
+	FaultBadSignal:            ErrBadSignal,
+	FaultNoFile:               ErrNoFile,
+	FaultNotExecutable:        ErrNotExecutable,
+	FaultFailed:               ErrFailed,
+	FaultAbnormalTermination:  ErrAbnormalTermination,
+	FaultSpawnError:           ErrSpawnError,
+	FaultAlreadyStarted:       ErrAlreadyStarted,
+	FaultNotRunning:           ErrNotRunning,
+	FaultAlreadyAdded:         ErrAlreadyAdded,
+	FaultStillRunning:         ErrStillRunning,
+	FaultCantReread:           ErrCantReread,
+}
+
+// Is 使 errors.Is(err, ErrNotRunning) 等判断可以匹配对应代码的 *Fault
+func (f *Fault) Is(target error) bool {
+	sentinel, ok := faultErrors[f.Code]
+	return ok && sentinel == target
+}
+
+// IsFault 判断错误是否为Supervisor返回的fault（而不是传输层错误）
+func IsFault(err error) bool {
+	var fault *Fault
+	return errors.As(err, &fault)
+}
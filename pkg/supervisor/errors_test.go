@@ -0,0 +1,41 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFault_Is 测试fault代码与错误值的匹配
+func TestFault_Is(t *testing.T) {
+	err := fmt.Errorf("stop进程失败: %w", &Fault{Code: FaultNotRunning, String: "NOT_RUNNING: web"})
+
+	assert.True(t, errors.Is(err, ErrNotRunning))
+	assert.False(t, errors.Is(err, ErrAlreadyStarted))
+	assert.True(t, IsFault(err))
+
+	var fault *Fault
+	require.True(t, errors.As(err, &fault))
+	assert.Equal(t, "NOT_RUNNING: web", fault.String)
+
+	assert.False(t, errors.Is(&Fault{Code: 999}, ErrFailed))
+	assert.False(t, IsFault(errors.New("connection refused")))
+}
+
+// TestControlProcess_RestartStoppedProcess 测试重启未运行的进程时直接启动
+func TestControlProcess_RestartStoppedProcess(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	table := newFakeProcessTable(fs, "web:web")
+	table.get("web:web").state = ProcessStateStopped
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = time.Millisecond
+	require.NoError(t, ctrl.ControlProcess(context.Background(), "restart", "web:web"))
+	assert.Equal(t, "supervisor.stopProcess", fs.methods()[0])
+	assert.Equal(t, []string{"web:web"}, table.startedNames())
+}
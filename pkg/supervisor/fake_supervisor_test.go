@@ -0,0 +1,329 @@
+package supervisor
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeHandler 模拟单个XML-RPC方法，返回结果或 *Fault
+type fakeHandler func(params []interface{}) (interface{}, error)
+
+// fakeSupervisor 用于测试的模拟supervisord XML-RPC服务
+type fakeSupervisor struct {
+	t        *testing.T
+	mu       sync.Mutex
+	handlers map[string]fakeHandler
+	calls    []string // 已执行的方法，multicall中的每个方法单独记录
+	requests []string // 每次HTTP请求调用的方法
+}
+
+// newFakeSupervisor 启动模拟服务并返回指向它的客户端
+func newFakeSupervisor(t *testing.T) (*fakeSupervisor, *RPCClient) {
+	fs := &fakeSupervisor{t: t, handlers: make(map[string]fakeHandler)}
+	server := httptest.NewServer(fs)
+	t.Cleanup(server.Close)
+	return fs, NewRPCClient(server.URL+"/RPC2", "", "")
+}
+
+// handle 注册方法处理函数
+func (fs *fakeSupervisor) handle(method string, handler fakeHandler) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	fs.handlers[method] = handler
+}
+
+// result 注册返回固定结果的方法
+func (fs *fakeSupervisor) result(method string, result interface{}) {
+	fs.handle(method, func([]interface{}) (interface{}, error) { return result, nil })
+}
+
+// methods 返回已调用的方法列表
+func (fs *fakeSupervisor) methods() []string {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return append([]string(nil), fs.calls...)
+}
+
+// requestMethods 返回每次HTTP请求调用的方法
+func (fs *fakeSupervisor) requestMethods() []string {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return append([]string(nil), fs.requests...)
+}
+
+// ServeHTTP 实现http.Handler
+func (fs *fakeSupervisor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	method, params, err := decodeMethodCall(body)
+	if err != nil {
+		fs.t.Errorf("无效的请求: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fs.mu.Lock()
+	fs.requests = append(fs.requests, method)
+	fs.mu.Unlock()
+
+	var data []byte
+	if method == "system.multicall" {
+		data, _ = encodeMethodResponse(fs.multicall(params))
+	} else if result, err := fs.dispatch(method, params); err != nil {
+		data, _ = encodeFault(err.(*Fault))
+	} else {
+		data, _ = encodeMethodResponse(result)
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	w.Write(data)
+}
+
+// dispatch 记录并执行单个方法调用
+func (fs *fakeSupervisor) dispatch(method string, params []interface{}) (interface{}, error) {
+	fs.mu.Lock()
+	fs.calls = append(fs.calls, method)
+	handler, ok := fs.handlers[method]
+	fs.mu.Unlock()
+
+	if !ok {
+		return nil, &Fault{Code: FaultUnknownMethod, String: "UNKNOWN_METHOD"}
+	}
+	return handler(params)
+}
+
+// multicall 按supervisord的格式执行system.multicall
+func (fs *fakeSupervisor) multicall(params []interface{}) []interface{} {
+	var calls []MulticallCall
+	if err := DecodeValue(params[0], &calls); err != nil {
+		fs.t.Errorf("无效的multicall参数: %v", err)
+	}
+	results := make([]interface{}, len(calls))
+	for i, call := range calls {
+		result, err := fs.dispatch(call.Method, call.Params)
+		if err != nil {
+			fault := err.(*Fault)
+			results[i] = map[string]interface{}{"faultCode": fault.Code, "faultString": fault.String}
+		} else {
+			results[i] = []interface{}{result}
+		}
+	}
+	return results
+}
+
+// fakeProcess 模拟的进程
+type fakeProcess struct {
+	group, name string
+	state       int
+	spawnErr    string
+	startState  int       // 启动后进入的状态，默认RUNNING；为STARTING时一直处于启动中
+	stderr      string    // 标准错误日志内容
+	stoppedAt   time.Time // 处于STOPPING时进入STOPPED的时间
+}
+
+// fakeProcessTable 模拟supervisord的进程表：stopProcess后为STOPPED，
+// startProcess后为STARTING，被查询一次后进入startState
+type fakeProcessTable struct {
+	mu        sync.Mutex
+	processes []*fakeProcess
+	started   []string
+	stopDelay time.Duration // 非零时停止后先处于STOPPING，经过stopDelay后进入STOPPED
+}
+
+// newFakeProcessTable 注册进程表相关的方法，进程初始状态为RUNNING
+func newFakeProcessTable(fs *fakeSupervisor, names ...string) *fakeProcessTable {
+	table := &fakeProcessTable{}
+	for _, name := range names {
+		group, short, _ := strings.Cut(name, ":")
+		table.processes = append(table.processes, &fakeProcess{group: group, name: short, state: ProcessStateRunning, startState: ProcessStateRunning})
+	}
+
+	fs.handle("supervisor.getAllProcessInfo", func([]interface{}) (interface{}, error) {
+		table.mu.Lock()
+		defer table.mu.Unlock()
+		var infos []interface{}
+		for _, p := range table.processes {
+			if p.state == ProcessStateStopping && !time.Now().Before(p.stoppedAt) {
+				p.state = ProcessStateStopped
+			}
+			infos = append(infos, map[string]interface{}{
+				"group": p.group, "name": p.name, "state": p.state, "statename": fakeStateNames[p.state], "spawnerr": p.spawnErr,
+			})
+			// 启动中的进程在下一次查询时进入startState
+			if p.state == ProcessStateStarting {
+				p.state = p.startState
+			}
+		}
+		return infos, nil
+	})
+	fs.handle("supervisor.stopProcess", func(params []interface{}) (interface{}, error) {
+		result, err := table.transition(params[0].(string), ProcessStateStopped)
+		if err == nil && params[1] == true {
+			time.Sleep(table.stopDelay) // supervisord等待进程退出
+		}
+		return result, err
+	})
+	fs.handle("supervisor.stopProcessGroup", func(params []interface{}) (interface{}, error) {
+		var statuses []interface{}
+		for _, p := range table.processes {
+			if p.group != params[0] {
+				continue
+			}
+			status := map[string]interface{}{"name": p.name, "group": p.group, "status": FaultSuccess, "description": "OK"}
+			if _, err := table.transition(p.group+":"+p.name, ProcessStateStopped); err != nil {
+				status["status"], status["description"] = err.(*Fault).Code, err.(*Fault).String
+			}
+			statuses = append(statuses, status)
+		}
+		if params[1] == true {
+			time.Sleep(table.stopDelay)
+		}
+		return statuses, nil
+	})
+	fs.handle("supervisor.startProcess", func(params []interface{}) (interface{}, error) {
+		return table.transition(params[0].(string), -1)
+	})
+	fs.handle("supervisor.tailProcessStderrLog", func(params []interface{}) (interface{}, error) {
+		p := table.get(params[0].(string))
+		return []interface{}{p.stderr, len(p.stderr), false}, nil
+	})
+	return table
+}
+
+// fakeStateNames 进程状态码对应的名称
+var fakeStateNames = map[int]string{
+	ProcessStateStopped: "STOPPED", ProcessStateStarting: "STARTING", ProcessStateRunning: "RUNNING",
+	ProcessStateBackoff: "BACKOFF", ProcessStateStopping: "STOPPING", ProcessStateExited: "EXITED",
+	ProcessStateFatal: "FATAL", ProcessStateUnknown: "UNKNOWN",
+}
+
+// get 按完整进程名查找进程
+func (table *fakeProcessTable) get(name string) *fakeProcess {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	for _, p := range table.processes {
+		if p.group+":"+p.name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// transition 执行启动或停止，state为-1时表示启动
+func (table *fakeProcessTable) transition(name string, state int) (interface{}, error) {
+	p := table.get(name)
+	if p == nil {
+		return nil, &Fault{Code: FaultBadName, String: "BAD_NAME: " + name}
+	}
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	if state == ProcessStateStopped {
+		if p.state != ProcessStateRunning && p.state != ProcessStateStarting && p.state != ProcessStateBackoff {
+			return nil, &Fault{Code: FaultNotRunning, String: "NOT_RUNNING: " + name}
+		}
+		p.state = ProcessStateStopped
+		if table.stopDelay > 0 {
+			p.state, p.stoppedAt = ProcessStateStopping, time.Now().Add(table.stopDelay)
+		}
+		return true, nil
+	}
+	if p.state == ProcessStateRunning || p.state == ProcessStateStarting {
+		return nil, &Fault{Code: FaultAlreadyStarted, String: "ALREADY_STARTED: " + name}
+	}
+	table.started = append(table.started, name)
+	p.state = ProcessStateStarting
+	return true, nil
+}
+
+// startedNames 返回已启动的进程
+func (table *fakeProcessTable) startedNames() []string {
+	table.mu.Lock()
+	defer table.mu.Unlock()
+	return append([]string(nil), table.started...)
+}
+
+// encodeMethodResponse 将返回值编码为XML-RPC响应体，供模拟服务使用
+func encodeMethodResponse(result interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodResponse><params><param>")
+	if err := encodeValue(&buf, reflect.ValueOf(result)); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</param></params></methodResponse>")
+	return buf.Bytes(), nil
+}
+
+// encodeFault 将fault编码为XML-RPC响应体
+func encodeFault(fault *Fault) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodResponse><fault>")
+	if err := encodeValue(&buf, reflect.ValueOf(fault)); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</fault></methodResponse>")
+	return buf.Bytes(), nil
+}
+
+// decodeMethodCall 解析XML-RPC请求，返回方法名和通用参数值
+func decodeMethodCall(data []byte) (string, []interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	root, ok, err := nextStart(dec)
+	if err != nil {
+		return "", nil, fmt.Errorf("XML解析失败: %v", err)
+	}
+	if !ok || root.Name.Local != "methodCall" {
+		return "", nil, fmt.Errorf("XML解析失败: 缺少methodCall元素")
+	}
+
+	var method string
+	params := []interface{}{}
+	for {
+		elem, ok, err := nextStart(dec)
+		if err != nil {
+			return "", nil, fmt.Errorf("XML解析失败: %v", err)
+		}
+		if !ok {
+			break
+		}
+		switch elem.Name.Local {
+		case "methodName":
+			if method, err = readText(dec, elem); err != nil {
+				return "", nil, fmt.Errorf("XML解析失败: %v", err)
+			}
+		case "params":
+			for {
+				param, ok, err := nextStart(dec)
+				if err != nil {
+					return "", nil, fmt.Errorf("XML解析失败: %v", err)
+				}
+				if !ok {
+					break
+				}
+				value, err := expectValue(dec)
+				if err != nil {
+					return "", nil, fmt.Errorf("XML解析失败: %v", err)
+				}
+				// 读取</param>
+				if _, ok, err := nextStart(dec); err != nil || ok {
+					return "", nil, fmt.Errorf("XML解析失败: <%s>格式无效", param.Name.Local)
+				}
+				params = append(params, value)
+			}
+		default:
+			return "", nil, fmt.Errorf("XML解析失败: 意外的元素 <%s>", elem.Name.Local)
+		}
+	}
+	if method == "" {
+		return "", nil, fmt.Errorf("XML解析失败: 缺少methodName")
+	}
+	return method, params, nil
+}
@@ -0,0 +1,163 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LogStream 进程的日志流
+type LogStream string
+
+const (
+	LogStdout LogStream = "stdout"
+	LogStderr LogStream = "stderr"
+)
+
+// DefaultLogChunkSize 跟踪日志时单次请求的最大字节数
+const DefaultLogChunkSize = 64 * 1024
+
+// DefaultLogCatchUp 新增内容超过单次tail读取量时，最多补读的字节数
+const DefaultLogCatchUp = 1024 * 1024
+
+// DefaultLogPollInterval 跟踪日志时的轮询间隔
+const DefaultLogPollInterval = 500 * time.Millisecond
+
+// TailProcessLog 按日志流类型调用 tailProcessStdoutLog/tailProcessStderrLog
+func (rc *RPCClient) TailProcessLog(ctx context.Context, name string, stream LogStream, offset, length int) (LogTail, error) {
+	switch stream {
+	case LogStdout:
+		return rc.TailProcessStdoutLog(ctx, name, offset, length)
+	case LogStderr:
+		return rc.TailProcessStderrLog(ctx, name, offset, length)
+	default:
+		return LogTail{}, fmt.Errorf("未知的日志流: %s", stream)
+	}
+}
+
+// ReadProcessLog 按日志流类型调用 readProcessStdoutLog/readProcessStderrLog
+func (rc *RPCClient) ReadProcessLog(ctx context.Context, name string, stream LogStream, offset, length int) (string, error) {
+	switch stream {
+	case LogStdout:
+		return rc.ReadProcessStdoutLog(ctx, name, offset, length)
+	case LogStderr:
+		return rc.ReadProcessStderrLog(ctx, name, offset, length)
+	default:
+		return "", fmt.Errorf("未知的日志流: %s", stream)
+	}
+}
+
+// LogChunk 跟踪日志时读取到的一段新内容
+type LogChunk struct {
+	Data     string // 新增的日志内容
+	Overflow bool   // 日志增长过快，与上一段之间有内容被跳过
+	Rotated  bool   // 日志文件被轮转或清空，从新文件开头读取
+}
+
+// LogFollower 基于 tail*Log 的offset/overflow协议持续跟踪一个进程的日志
+//
+// tail*Log 总是返回以日志末尾结束的数据，并在offset中返回当前日志大小。
+// LogFollower 根据已读取的位置只截取新增部分，因此不会重复输出；
+// 新增内容超过ChunkSize时用 read*Log 补读中间缺失的部分，超过MaxCatchUp才跳过。
+// 日志大小小于已读取位置时视为发生了轮转，从新文件开头重新读取。
+type LogFollower struct {
+	client     *RPCClient
+	name       string
+	stream     LogStream
+	offset     int
+	ChunkSize  int // 单次请求的最大字节数
+	MaxCatchUp int // 最多补读的字节数
+}
+
+// NewLogFollower 创建日志跟踪器
+func NewLogFollower(client *RPCClient, name string, stream LogStream) *LogFollower {
+	return &LogFollower{
+		client:     client,
+		name:       name,
+		stream:     stream,
+		ChunkSize:  DefaultLogChunkSize,
+		MaxCatchUp: DefaultLogCatchUp,
+	}
+}
+
+// Name 返回跟踪的进程名
+func (f *LogFollower) Name() string {
+	return f.name
+}
+
+// Stream 返回跟踪的日志流
+func (f *LogFollower) Stream() LogStream {
+	return f.stream
+}
+
+// Start 读取日志末尾最多length字节，并从日志末尾开始跟踪
+func (f *LogFollower) Start(ctx context.Context, length int) (LogChunk, error) {
+	tail, err := f.client.TailProcessLog(ctx, f.name, f.stream, 0, length)
+	if err != nil {
+		return LogChunk{}, err
+	}
+	f.offset = tail.Offset
+	// 首次读取本来就只需要末尾部分，不视为溢出
+	return LogChunk{Data: tail.Bytes}, nil
+}
+
+// Poll 读取自上次读取以来新增的日志
+func (f *LogFollower) Poll(ctx context.Context) (LogChunk, error) {
+	tail, err := f.client.TailProcessLog(ctx, f.name, f.stream, f.offset, f.ChunkSize)
+	if err != nil {
+		return LogChunk{}, err
+	}
+
+	var chunk LogChunk
+	if tail.Offset < f.offset {
+		// 日志变小说明被轮转或清空，从新文件开头读取
+		f.offset = 0
+		chunk, err = f.Poll(ctx)
+		chunk.Rotated = true
+		return chunk, err
+	}
+
+	newBytes := tail.Offset - f.offset
+	switch {
+	case newBytes <= 0:
+	case newBytes <= len(tail.Bytes):
+		chunk.Data = tail.Bytes[len(tail.Bytes)-newBytes:]
+	case newBytes-len(tail.Bytes) <= f.MaxCatchUp:
+		// tail只返回了末尾部分，补读上次位置到这部分之间的内容
+		missing, err := f.client.ReadProcessLog(ctx, f.name, f.stream, f.offset, newBytes-len(tail.Bytes))
+		if err != nil {
+			return LogChunk{}, err
+		}
+		chunk.Data = missing + tail.Bytes
+	default:
+		chunk.Data = tail.Bytes
+		chunk.Overflow = true
+	}
+	f.offset = tail.Offset
+	return chunk, nil
+}
+
+// Follow 持续轮询日志，每次有新内容时调用handler，直到ctx取消或handler返回错误
+func (f *LogFollower) Follow(ctx context.Context, interval time.Duration, handler func(LogChunk) error) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		chunk, err := f.Poll(ctx)
+		if err != nil {
+			return err
+		}
+		if chunk.Data == "" && !chunk.Overflow && !chunk.Rotated {
+			continue
+		}
+		if err := handler(chunk); err != nil {
+			return err
+		}
+	}
+}
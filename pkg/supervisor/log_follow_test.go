@@ -0,0 +1,191 @@
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLog 模拟进程日志文件，按supervisord的tailFile规则返回数据
+type fakeLog struct {
+	mu   sync.Mutex
+	data string
+}
+
+// write 追加日志
+func (l *fakeLog) write(s string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.data += s
+}
+
+// rotate 模拟日志轮转，新文件内容为s
+func (l *fakeLog) rotate(s string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.data = s
+}
+
+// tail 与supervisor.options.tailFile的行为一致
+func (l *fakeLog) tail(params []interface{}) (interface{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	offset, length := params[1].(int), params[2].(int)
+	size := len(l.data)
+	overflow := false
+	if size > offset+length {
+		overflow = true
+		offset = size - 1
+	}
+	if offset+length > size {
+		if offset > size-1 {
+			length = 0
+		}
+		offset = size - length
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	end := offset + length
+	if end > size {
+		end = size
+	}
+	return []interface{}{l.data[offset:end], size, overflow}, nil
+}
+
+// read 与supervisor.options.readFile对正数offset/length的行为一致
+func (l *fakeLog) read(params []interface{}) (interface{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	offset, length := params[1].(int), params[2].(int)
+	if offset > len(l.data) {
+		offset = len(l.data)
+	}
+	end := offset + length
+	if length == 0 || end > len(l.data) {
+		end = len(l.data)
+	}
+	return l.data[offset:end], nil
+}
+
+// newFakeLogFollower 创建跟踪模拟日志的LogFollower
+func newFakeLogFollower(t *testing.T, stream LogStream) (*fakeLog, *LogFollower) {
+	fs, client := newFakeSupervisor(t)
+	log := &fakeLog{}
+	fs.handle("supervisor.tailProcessStdoutLog", log.tail)
+	fs.handle("supervisor.tailProcessStderrLog", log.tail)
+	fs.handle("supervisor.readProcessStdoutLog", log.read)
+	fs.handle("supervisor.readProcessStderrLog", log.read)
+	return log, NewLogFollower(client, "web:web_00", stream)
+}
+
+// TestLogFollower_StartAndPoll 测试只返回新增内容，不重复输出
+func TestLogFollower_StartAndPoll(t *testing.T) {
+	log, follower := newFakeLogFollower(t, LogStdout)
+	ctx := context.Background()
+	log.write("line1\nline2\nline3\n")
+
+	chunk, err := follower.Start(ctx, 6)
+	require.NoError(t, err)
+	assert.Equal(t, LogChunk{Data: "line3\n"}, chunk)
+
+	chunk, err = follower.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, LogChunk{}, chunk)
+
+	log.write("line4\n")
+	chunk, err = follower.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, LogChunk{Data: "line4\n"}, chunk)
+}
+
+// TestLogFollower_CatchUp 测试日志增长超过单次读取量时补读缺失部分
+func TestLogFollower_CatchUp(t *testing.T) {
+	log, follower := newFakeLogFollower(t, LogStderr)
+	follower.ChunkSize = 4
+	ctx := context.Background()
+
+	_, err := follower.Start(ctx, 100)
+	require.NoError(t, err)
+
+	log.write("0123456789")
+	chunk, err := follower.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, LogChunk{Data: "0123456789"}, chunk)
+}
+
+// TestLogFollower_Overflow 测试新增内容超过补读上限时标记溢出
+func TestLogFollower_Overflow(t *testing.T) {
+	log, follower := newFakeLogFollower(t, LogStderr)
+	follower.ChunkSize = 4
+	follower.MaxCatchUp = 5
+	ctx := context.Background()
+
+	_, err := follower.Start(ctx, 100)
+	require.NoError(t, err)
+
+	log.write("0123456789")
+	chunk, err := follower.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, LogChunk{Data: "6789", Overflow: true}, chunk)
+}
+
+// TestLogFollower_Rotated 测试日志轮转后从新文件开头读取
+func TestLogFollower_Rotated(t *testing.T) {
+	log, follower := newFakeLogFollower(t, LogStdout)
+	ctx := context.Background()
+	log.write("old line 1\nold line 2\n")
+
+	_, err := follower.Start(ctx, 100)
+	require.NoError(t, err)
+
+	log.rotate("new\n")
+	chunk, err := follower.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, LogChunk{Data: "new\n", Rotated: true}, chunk)
+
+	// 轮转后的新文件超过单次读取量时同样补读
+	follower.ChunkSize = 2
+	log.rotate("ab\n")
+	chunk, err = follower.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, LogChunk{Data: "ab\n", Rotated: true}, chunk)
+
+	log.write("next\n")
+	chunk, err = follower.Poll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, LogChunk{Data: "next\n"}, chunk)
+}
+
+// TestLogFollower_Follow 测试持续跟踪直到ctx取消
+func TestLogFollower_Follow(t *testing.T) {
+	log, follower := newFakeLogFollower(t, LogStdout)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := follower.Start(ctx, 100)
+	require.NoError(t, err)
+	log.write("hello\n")
+
+	var got []string
+	err = follower.Follow(ctx, 10*time.Millisecond, func(chunk LogChunk) error {
+		got = append(got, chunk.Data)
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"hello\n"}, got)
+}
+
+// TestRPCClient_TailProcessLog_UnknownStream 测试未知日志流
+func TestRPCClient_TailProcessLog_UnknownStream(t *testing.T) {
+	_, client := newFakeSupervisor(t)
+	_, err := client.TailProcessLog(context.Background(), "web", LogStream("stdin"), 0, 10)
+	assert.Error(t, err)
+}
@@ -1,58 +1,189 @@
 package supervisor
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 )
 
 // ProcessController 负责控制Supervisor进程（启动/停止/重启）
-type ProcessController struct{}
+type ProcessController struct {
+	client       *RPCClient
+	fallback     bool
+	PollInterval time.Duration // 等待进程状态变化时轮询的间隔
+	Timeout      time.Duration // 等待进程停止、以及启动后进入RUNNING的最长时间
+}
 
-// NewProcessController 创建新的进程控制器
-func NewProcessController() *ProcessController {
-	return &ProcessController{}
+// NewProcessController 创建新的进程控制器，控制操作通过client的XML-RPC连接执行
+func NewProcessController(client *RPCClient) *ProcessController {
+	return &ProcessController{client: client, PollInterval: 500 * time.Millisecond, Timeout: 60 * time.Second}
 }
 
-// ControlProcess 控制进程（启动/停止/重启）
-func (pc *ProcessController) ControlProcess(action, processName string) error {
-	// 验证进程名称，防止命令注入
-	// 检查是否包含可能用于命令注入的特殊字符
-	if strings.ContainsAny(processName, "|;&`$()<>[]{}\\\"'") {
-		return fmt.Errorf("进程名称包含非法字符")
+// SetCommandFallback 设置RPC连接不可用时是否回退到本机的supervisorctl命令，默认不回退
+//
+// supervisorctl 只能控制本机的supervisord，因此只应在用户显式要求并且连接本地Supervisor时开启。
+func (pc *ProcessController) SetCommandFallback(enabled bool) {
+	pc.fallback = enabled
+}
+
+// ControlProcess 控制进程（启动/停止/重启），等待进程进入目标状态后返回
+func (pc *ProcessController) ControlProcess(ctx context.Context, action, processName string) error {
+	if err := validateProcessName(processName); err != nil {
+		return err
 	}
+	return pc.ControlBatch(ctx, action, []string{processName})[0]
+}
 
-	// 检查进程名是否只包含字母数字、冒号、下划线、连字符和点号（标准进程名格式）
-	// 避免包含可能导致shell解释的字符
-	for _, r := range processName {
-		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') ||
-			 r == ':' || r == '_' || r == '-' || r == '.') {
-			// 如果包含非标准字符，可能是恶意输入
-			return fmt.Errorf("进程名称包含非法字符")
+// ControlBatch 批量控制多个进程（启动/停止/重启），返回与names一一对应的错误
+//
+// 同一批次的操作通过 system.multicall 在一次请求中发送，supervisord不等待进程状态变化即返回，
+// 之后轮询进程状态直到进程停止或进入RUNNING，最长等待Timeout；重启的过程见 restartBatch。
+func (pc *ProcessController) ControlBatch(ctx context.Context, action string, names []string) []error {
+	errs := make([]error, len(names))
+	if action != "start" && action != "stop" && action != "restart" {
+		for i := range errs {
+			errs[i] = fmt.Errorf("不支持的操作: %s", action)
 		}
+		return errs
+	}
+
+	var valid []int
+	for i, name := range names {
+		if err := validateProcessName(name); err != nil {
+			errs[i] = err
+			continue
+		}
+		valid = append(valid, i)
 	}
 
-	var command string
 	switch action {
 	case "start":
-		command = "start"
+		pc.startBatch(ctx, names, valid, errs)
 	case "stop":
-		command = "stop"
-	case "restart":
-		// 重启是先停止再启动
-		err := pc.controlProcessViaCommand("stop", processName)
-		if err != nil {
-			return fmt.Errorf("停止进程失败: %v", err)
-		}
-		time.Sleep(1 * time.Second) // 等待一下再启动
-		return pc.controlProcessViaCommand("start", processName)
+		pc.stopBatch(ctx, names, valid, errs)
 	default:
+		pc.restartBatch(ctx, names, valid, errs)
+	}
+	return errs
+}
+
+// ControlParallel 使用workers个并发worker逐个控制进程，返回与names一一对应的错误
+//
+// 每个进程完成后调用report，report的调用是串行的，可以直接输出结果。
+// ctx取消后尚未开始的进程不再执行，返回的错误包含 ctx.Err()。
+func (pc *ProcessController) ControlParallel(ctx context.Context, action string, names []string, workers int, report func(index int, err error)) []error {
+	errs := make([]error, len(names))
+	jobs := make(chan int)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < min(max(workers, 1), len(names)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				var err error
+				if ctx.Err() != nil {
+					err = fmt.Errorf("%s进程失败: %w", action, ctx.Err())
+				} else {
+					err = pc.ControlProcess(ctx, action, names[i])
+				}
+				mu.Lock()
+				errs[i] = err
+				if report != nil {
+					report(i, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range names {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return errs
+}
+
+// multicallAction 通过一次multicall对indices中的进程执行启动/停止
+// supervisord不等待进程进入RUNNING/STOPPED即返回，由调用方轮询进程状态；
+// 通过supervisorctl执行时由supervisorctl等待，此时viaCommand为true
+func (pc *ProcessController) multicallAction(ctx context.Context, action string, names []string, indices []int) (errs []error, viaCommand bool) {
+	errs = make([]error, len(names))
+	if len(indices) == 0 {
+		return errs, false
+	}
+
+	// 已取消时不再发送请求，剩余的进程全部标记为取消
+	if ctx.Err() != nil {
+		for _, i := range indices {
+			errs[i] = fmt.Errorf("%s进程失败: %w", action, ctx.Err())
+		}
+		return errs, false
+	}
+
+	if pc.client == nil {
+		if !pc.fallback {
+			for _, i := range indices {
+				errs[i] = fmt.Errorf("%s进程失败: 未配置RPC客户端", action)
+			}
+			return errs, false
+		}
+		return pc.commandAction(ctx, action, names, indices), true
+	}
+
+	method := "supervisor.startProcess"
+	if action == "stop" {
+		method = "supervisor.stopProcess"
+	}
+	calls := make([]MulticallCall, len(indices))
+	for k, i := range indices {
+		calls[k] = MulticallCall{Method: method, Params: []interface{}{names[i], false}}
+	}
+
+	results, err := pc.client.Multicall(ctx, calls)
+	if err != nil {
+		// Supervisor返回的fault说明RPC连接正常，不应回退；用户取消时也不回退
+		if pc.fallback && !IsFault(err) && ctx.Err() == nil {
+			return pc.commandAction(ctx, action, names, indices), true
+		}
+		for _, i := range indices {
+			errs[i] = fmt.Errorf("%s进程失败: %w", action, err)
+		}
+		return errs, false
+	}
+
+	for k, i := range indices {
+		if results[k].Err != nil {
+			errs[i] = fmt.Errorf("%s进程失败: %w", action, results[k].Err)
+		}
+	}
+	return errs, false
+}
+
+// commandAction 通过supervisorctl逐个启动/停止indices中的进程
+func (pc *ProcessController) commandAction(ctx context.Context, action string, names []string, indices []int) []error {
+	errs := make([]error, len(names))
+	for _, i := range indices {
+		errs[i] = pc.controlProcessViaCommand(ctx, action, names[i])
+	}
+	return errs
+}
+
+// controlProcessViaCommand 通过supervisorctl命令控制进程（回退方案）
+func (pc *ProcessController) controlProcessViaCommand(ctx context.Context, action, processName string) error {
+	if err := validateProcessName(processName); err != nil {
+		return err
+	}
+
+	if action != "start" && action != "stop" {
 		return fmt.Errorf("不支持的操作: %s", action)
 	}
 
 	// 使用 supervisorctl 命令控制进程，使用参数化方式避免命令注入
-	cmd := exec.Command("supervisorctl", command, processName)
+	cmd := exec.CommandContext(ctx, "supervisorctl", action, processName)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("%s进程失败: %v, 输出: %s", action, err, string(output))
@@ -67,9 +198,8 @@ func (pc *ProcessController) ControlProcess(action, processName string) error {
 	return nil
 }
 
-// controlProcessViaCommand 通过命令行方式控制进程
-func (pc *ProcessController) controlProcessViaCommand(action, processName string) error {
-	// 验证进程名称，防止命令注入
+// validateProcessName 验证进程名称，防止命令注入
+func validateProcessName(processName string) error {
 	// 检查是否包含可能用于命令注入的特殊字符
 	if strings.ContainsAny(processName, "|;&`$()<>[]{}\\\"'") {
 		return fmt.Errorf("进程名称包含非法字符")
@@ -79,42 +209,10 @@ func (pc *ProcessController) controlProcessViaCommand(action, processName string
 	// 避免包含可能导致shell解释的字符
 	for _, r := range processName {
 		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') ||
-			 r == ':' || r == '_' || r == '-' || r == '.') {
+			r == ':' || r == '_' || r == '-' || r == '.') {
 			// 如果包含非标准字符，可能是恶意输入
 			return fmt.Errorf("进程名称包含非法字符")
 		}
 	}
-
-	var command string
-	switch action {
-	case "start":
-		command = "start"
-	case "stop":
-		command = "stop"
-	case "restart":
-		// 重启是先停止再启动
-		err := pc.controlProcessViaCommand("stop", processName)
-		if err != nil {
-			return fmt.Errorf("停止进程失败: %v", err)
-		}
-		time.Sleep(1 * time.Second) // 等待一下再启动
-		return pc.controlProcessViaCommand("start", processName)
-	default:
-		return fmt.Errorf("不支持的操作: %s", action)
-	}
-
-	// 使用 supervisorctl 命令控制进程，使用参数化方式避免命令注入
-	cmd := exec.Command("supervisorctl", command, processName)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("%s进程失败: %v, 输出: %s", action, err, string(output))
-	}
-
-	// 检查输出是否成功
-	outputStr := string(output)
-	if strings.Contains(outputStr, "ERROR") {
-		return fmt.Errorf("%s进程失败: %s", action, outputStr)
-	}
-
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,262 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestControlProcess_ViaRPC 测试启动/停止通过XML-RPC执行，不等待supervisord返回而是轮询进程状态
+func TestControlProcess_ViaRPC(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	table := newFakeProcessTable(fs, "web:web_00", "web:web_01")
+	table.get("web:web_00").state = ProcessStateStopped
+	var started, stopped []interface{}
+	fs.handle("supervisor.startProcess", func(params []interface{}) (interface{}, error) {
+		started = params
+		return table.transition(params[0].(string), -1)
+	})
+	fs.handle("supervisor.stopProcess", func(params []interface{}) (interface{}, error) {
+		stopped = params
+		return table.transition(params[0].(string), ProcessStateStopped)
+	})
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = time.Millisecond
+	require.NoError(t, ctrl.ControlProcess(context.Background(), "start", "web:web_00"))
+	require.NoError(t, ctrl.ControlProcess(context.Background(), "stop", "web:web_01"))
+	assert.Equal(t, []interface{}{"web:web_00", false}, started)
+	assert.Equal(t, []interface{}{"web:web_01", false}, stopped)
+	assert.Equal(t, ProcessStateRunning, table.get("web:web_00").state)
+	assert.Equal(t, ProcessStateStopped, table.get("web:web_01").state)
+}
+
+// TestControlProcess_FaultDoesNotFallback 测试fault不会触发supervisorctl回退
+func TestControlProcess_FaultDoesNotFallback(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.handle("supervisor.startProcess", func([]interface{}) (interface{}, error) {
+		return nil, &Fault{Code: 10, String: "BAD_NAME: nope"}
+	})
+
+	ctrl := NewProcessController(client)
+	ctrl.SetCommandFallback(true)
+	err := ctrl.ControlProcess(context.Background(), "start", "nope")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BAD_NAME")
+}
+
+// TestControlProcess_Invalid 测试无效操作和非法进程名
+func TestControlProcess_Invalid(t *testing.T) {
+	_, client := newFakeSupervisor(t)
+	ctrl := NewProcessController(client)
+
+	err := ctrl.ControlProcess(context.Background(), "invalid", "web")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "不支持的操作: invalid")
+
+	err = ctrl.ControlProcess(context.Background(), "start", "web;rm -rf /")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "非法字符")
+}
+
+// TestRPCClient_IsLocal 测试本地地址判断
+func TestRPCClient_IsLocal(t *testing.T) {
+	assert.True(t, NewRPCClient("http://localhost:9001/RPC2", "", "").IsLocal())
+	assert.True(t, NewRPCClient("http://127.0.0.1:9001/RPC2", "", "").IsLocal())
+	assert.False(t, NewRPCClient("http://10.0.0.5:9001/RPC2", "", "").IsLocal())
+}
+
+// TestControlBatch_Multicall 测试批量操作通过一次multicall完成并逐个返回结果
+func TestControlBatch_Multicall(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	table := newFakeProcessTable(fs, "web:web_00", "web:web_01")
+	table.get("web:web_00").state = ProcessStateStopped
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = time.Millisecond
+	errs := ctrl.ControlBatch(context.Background(), "start", []string{"web:web_00", "web:web_01", "missing", "bad;name"})
+	require.Len(t, errs, 4)
+	assert.NoError(t, errs[0])
+	assert.True(t, errors.Is(errs[1], ErrAlreadyStarted))
+	assert.True(t, errors.Is(errs[2], ErrBadName))
+	assert.Contains(t, errs[3].Error(), "非法字符")
+
+	// 只有一次multicall请求，其中包含三个有效进程，之后只查询进程状态
+	requests := fs.requestMethods()
+	require.NotEmpty(t, requests)
+	assert.Equal(t, "system.multicall", requests[0])
+	assert.NotContains(t, requests[1:], "system.multicall")
+	assert.Equal(t, []string{"supervisor.startProcess", "supervisor.startProcess", "supervisor.startProcess"}, fs.methods()[:3])
+	assert.Equal(t, ProcessStateRunning, table.get("web:web_00").state)
+}
+
+// TestControlBatch_SlowStart 测试启动大量进程时不会超过单次RPC调用的超时
+//
+// supervisord串行执行multicall中的调用，等待每个进程启动会使整个请求耗时随进程数增长。
+func TestControlBatch_SlowStart(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	client.SetTimeout(200 * time.Millisecond)
+	var names []string
+	for i := 0; i < 40; i++ {
+		names = append(names, fmt.Sprintf("worker:worker_%02d", i))
+	}
+	table := newFakeProcessTable(fs, names...)
+	for _, name := range names {
+		table.get(name).state = ProcessStateStopped
+	}
+	fs.handle("supervisor.startProcess", func(params []interface{}) (interface{}, error) {
+		if params[1] == true {
+			time.Sleep(50 * time.Millisecond) // 等待startsecs
+		}
+		return table.transition(params[0].(string), -1)
+	})
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = time.Millisecond
+	for i, err := range ctrl.ControlBatch(context.Background(), "start", names) {
+		assert.NoError(t, err, names[i])
+	}
+	assert.Len(t, table.startedNames(), 40)
+}
+
+// TestControlBatch_Restart 测试批量重启先停止再启动，停止失败的进程不会被启动
+func TestControlBatch_Restart(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	table := newFakeProcessTable(fs, "api:a", "api:b", "api:c")
+	table.get("api:b").state = ProcessStateStopped
+	fs.handle("supervisor.stopProcess", func(params []interface{}) (interface{}, error) {
+		if params[0] == "api:c" {
+			return nil, &Fault{Code: FaultFailed, String: "FAILED: api:c"}
+		}
+		return table.transition(params[0].(string), ProcessStateStopped)
+	})
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = time.Millisecond
+	errs := ctrl.ControlBatch(context.Background(), "restart", []string{"api:a", "api:b", "api:c"})
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.True(t, errors.Is(errs[2], ErrFailed))
+	assert.Equal(t, []string{"api:a", "api:b"}, table.startedNames())
+	assert.Equal(t, ProcessStateRunning, table.get("api:a").state)
+}
+
+// TestControlBatch_RestartSlowStop 测试进程停止耗时超过单次RPC调用的超时时重启仍然成功
+func TestControlBatch_RestartSlowStop(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	client.SetTimeout(100 * time.Millisecond)
+	table := newFakeProcessTable(fs, "api:a", "api:b")
+	table.stopDelay = 300 * time.Millisecond // 例如stopwaitsecs较大并且忽略SIGTERM的进程
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = 10 * time.Millisecond
+	errs := ctrl.ControlBatch(context.Background(), "restart", []string{"api:a", "api:b"})
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.Equal(t, []string{"api:a", "api:b"}, table.startedNames())
+}
+
+// TestControlBatch_Cancelled 测试取消后未完成的操作返回context.Canceled
+func TestControlBatch_Cancelled(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	table := newFakeProcessTable(fs, "api:a", "api:b")
+	ctx, cancel := context.WithCancel(context.Background())
+	fs.handle("supervisor.stopProcess", func(params []interface{}) (interface{}, error) {
+		cancel() // 停止完成后用户按下Ctrl-C
+		return table.transition(params[0].(string), ProcessStateStopped)
+	})
+
+	errs := NewProcessController(client).ControlBatch(ctx, "restart", []string{"api:a", "api:b"})
+	for _, err := range errs {
+		assert.True(t, errors.Is(err, context.Canceled))
+	}
+	assert.NotContains(t, fs.methods(), "supervisor.startProcess")
+}
+
+// TestControlProcess_RestartFailed 测试重启后进程进入FATAL时返回启动错误和最后几行标准错误
+func TestControlProcess_RestartFailed(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	table := newFakeProcessTable(fs, "api:api")
+	p := table.get("api:api")
+	p.startState = ProcessStateFatal
+	p.spawnErr = "Exited too quickly (process log may have details)"
+	p.stderr = "line1\nline2\nline3\nline4\nline5\npanic: missing DATABASE_URL\n"
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = time.Millisecond
+	err := ctrl.ControlProcess(context.Background(), "restart", "api:api")
+
+	var startErr *StartError
+	require.True(t, errors.As(err, &startErr))
+	assert.True(t, errors.Is(err, ErrSpawnError))
+	assert.Equal(t, "FATAL", startErr.State)
+	assert.Equal(t, "启动后进程进入FATAL状态: Exited too quickly (process log may have details)", err.Error())
+	assert.Equal(t, []string{"line2", "line3", "line4", "line5", "panic: missing DATABASE_URL"}, startErr.StderrTail)
+
+	// startProcess 不等待，由sv轮询状态
+	assert.Contains(t, fs.methods(), "supervisor.getAllProcessInfo")
+}
+
+// TestControlProcess_RestartTimeout 测试进程一直处于STARTING时超时
+func TestControlProcess_RestartTimeout(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	table := newFakeProcessTable(fs, "api:api")
+	table.get("api:api").startState = ProcessStateStarting
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = time.Millisecond
+	ctrl.Timeout = 30 * time.Millisecond
+	err := ctrl.ControlProcess(context.Background(), "restart", "api:api")
+
+	var startErr *StartError
+	require.True(t, errors.As(err, &startErr))
+	assert.Equal(t, 30*time.Millisecond, startErr.Timeout)
+	assert.Equal(t, "STARTING", startErr.State)
+	assert.False(t, errors.Is(err, ErrSpawnError))
+}
+
+// TestControlParallel 测试并发数受限并且每个进程的结果都被报告
+func TestControlParallel(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	names := []string{"x:a", "x:b", "x:missing", "x:d", "x:e"}
+	table := newFakeProcessTable(fs, "x:a", "x:b", "x:d", "x:e")
+	for _, p := range table.processes {
+		p.state = ProcessStateStopped
+	}
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	fs.handle("supervisor.startProcess", func(params []interface{}) (interface{}, error) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return table.transition(params[0].(string), -1)
+	})
+
+	reported := make(map[int]error)
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = time.Millisecond
+	errs := ctrl.ControlParallel(context.Background(), "start", names, 2,
+		func(index int, err error) { reported[index] = err })
+
+	require.Len(t, errs, 5)
+	assert.True(t, errors.Is(errs[2], ErrBadName))
+	for i, err := range errs {
+		if i != 2 {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, err, reported[i])
+	}
+	assert.Len(t, reported, 5)
+	assert.Equal(t, 2, maxInFlight)
+}
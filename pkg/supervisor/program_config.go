@@ -0,0 +1,95 @@
+package supervisor
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// programSectionPrefixes 定义进程组的配置段前缀
+var programSectionPrefixes = []string{"program:", "fcgi-program:", "eventlistener:"}
+
+// ProgramConfig 配置文件中一个进程组的配置段
+type ProgramConfig struct {
+	Section string            // 段名，例如 program:web
+	File    string            // 所在的配置文件
+	Values  map[string]string // 原始配置值，未展开 %(program_name)s 等表达式
+}
+
+// FindProgramConfig 在主配置文件和 [include] 引入的文件中查找进程的配置段
+//
+// 先按进程组名查找 [program:<group>]；进程组由 [group:<group>] 的programs组成时，
+// 按进程名在programs中找到对应的program再查找。
+func (cd *ConfigDetector) FindProgramConfig(configPath, group, name string) (ProgramConfig, error) {
+	files, err := configFiles(configPath)
+	if err != nil {
+		return ProgramConfig{}, err
+	}
+
+	// 同名的段以先出现的为准
+	sections := make(map[string]ProgramConfig)
+	for _, file := range files {
+		fileSections, err := readConfigSections(file)
+		if err != nil {
+			continue
+		}
+		for section, values := range fileSections {
+			if _, ok := sections[section]; !ok {
+				sections[section] = ProgramConfig{Section: section, File: file, Values: values}
+			}
+		}
+	}
+
+	programs := []string{group}
+	if groupSection, ok := sections["group:"+group]; ok {
+		if program := groupProgram(groupSection.Values["programs"], name); program != "" {
+			programs = append(programs, program)
+		}
+	}
+	for _, program := range programs {
+		for _, prefix := range programSectionPrefixes {
+			if config, ok := sections[prefix+program]; ok {
+				return config, nil
+			}
+		}
+	}
+	return ProgramConfig{}, fmt.Errorf("未在配置文件中找到 [program:%s]", group)
+}
+
+// groupProgram 在 [group:*] 的programs列表中找到进程所属的program
+// 进程名与program相同，或者以program开头（numprocs大于1时的 %(process_num)s 后缀）
+func groupProgram(programs, name string) string {
+	var best string
+	for _, program := range strings.Split(programs, ",") {
+		program = strings.TrimSpace(program)
+		switch {
+		case program == "":
+		case program == name:
+			return program
+		case strings.HasPrefix(name, program) && len(program) > len(best):
+			best = program
+		}
+	}
+	return best
+}
+
+// configFiles 返回主配置文件及其 [include] files 匹配的文件，相对路径相对于主配置文件所在目录
+func configFiles(configPath string) ([]string, error) {
+	include, err := readConfigSection(configPath, "include")
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{configPath}
+	for _, pattern := range strings.Fields(include["files"]) {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(configPath), pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			continue
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
@@ -0,0 +1,89 @@
+package supervisor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFindProgramConfig 测试在主配置文件和[include]文件中查找进程组配置
+func TestFindProgramConfig(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "supervisord.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte(`[supervisord]
+logfile=/var/log/supervisor/supervisord.log
+
+[program:cron]
+command=/usr/sbin/cron -f
+
+[include]
+files = conf.d/*.conf
+`), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "conf.d"), 0755))
+	webPath := filepath.Join(dir, "conf.d", "web.conf")
+	require.NoError(t, os.WriteFile(webPath, []byte(`[program:web]
+command=/srv/web/bin/server --port 8080 ; 监听端口
+directory=/srv/web
+user=www-data
+environment=
+    APP_ENV="production",
+    PORT="8080"
+autorestart=true
+`), 0644))
+
+	cd := NewConfigDetector()
+
+	config, err := cd.FindProgramConfig(configPath, "web", "web")
+	require.NoError(t, err)
+	assert.Equal(t, "program:web", config.Section)
+	assert.Equal(t, webPath, config.File)
+	assert.Equal(t, "/srv/web/bin/server --port 8080", config.Values["command"])
+	assert.Equal(t, "www-data", config.Values["user"])
+	assert.Equal(t, `APP_ENV="production", PORT="8080"`, config.Values["environment"])
+	assert.Equal(t, "true", config.Values["autorestart"])
+
+	config, err = cd.FindProgramConfig(configPath, "cron", "cron")
+	require.NoError(t, err)
+	assert.Equal(t, configPath, config.File)
+	assert.Equal(t, "/usr/sbin/cron -f", config.Values["command"])
+
+	_, err = cd.FindProgramConfig(configPath, "missing", "missing")
+	assert.Error(t, err)
+}
+
+// TestFindProgramConfig_Group 测试 [group:*] 中的program按进程名查找
+func TestFindProgramConfig_Group(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "supervisord.conf")
+	require.NoError(t, os.WriteFile(configPath, []byte(`[group:backend]
+programs = api, worker
+
+[program:api]
+command=/srv/api/bin/api
+user=api
+
+[program:worker]
+command=/srv/worker/bin/worker --queue %(process_num)s
+process_name=%(program_name)s_%(process_num)02d
+numprocs=2
+environment=QUEUE="default"
+`), 0644))
+
+	cd := NewConfigDetector()
+
+	config, err := cd.FindProgramConfig(configPath, "backend", "api")
+	require.NoError(t, err)
+	assert.Equal(t, "program:api", config.Section)
+	assert.Equal(t, "api", config.Values["user"])
+
+	config, err = cd.FindProgramConfig(configPath, "backend", "worker_01")
+	require.NoError(t, err)
+	assert.Equal(t, "program:worker", config.Section)
+	assert.Equal(t, `QUEUE="default"`, config.Values["environment"])
+
+	_, err = cd.FindProgramConfig(configPath, "backend", "scheduler")
+	assert.Error(t, err)
+}
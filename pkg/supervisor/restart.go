@@ -0,0 +1,229 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// stderrTailLines 启动失败时附带的标准错误日志行数
+const stderrTailLines = 5
+
+// stderrTailBytes 读取标准错误日志末尾的字节数
+const stderrTailBytes = 4096
+
+// StartError 启动或重启后进程没有进入RUNNING
+type StartError struct {
+	State      string        // 最后观察到的状态，例如 FATAL、BACKOFF
+	SpawnErr   string        // supervisord记录的启动错误
+	StderrTail []string      // 标准错误日志的最后几行
+	Timeout    time.Duration // 非零表示等待超时，进程仍未进入RUNNING
+}
+
+// Error 实现error接口
+func (e *StartError) Error() string {
+	var msg string
+	if e.Timeout > 0 {
+		msg = fmt.Sprintf("等待进程进入RUNNING超时 (%s)，当前状态: %s", e.Timeout, e.State)
+	} else {
+		msg = fmt.Sprintf("启动后进程进入%s状态", e.State)
+	}
+	if e.SpawnErr != "" {
+		msg += ": " + e.SpawnErr
+	}
+	return msg
+}
+
+// Unwrap 进程进入失败状态时视为 ErrSpawnError，超时则不是
+func (e *StartError) Unwrap() error {
+	if e.Timeout > 0 {
+		return nil
+	}
+	return ErrSpawnError
+}
+
+// restartBatch 重启indices中的进程，结果写入errs
+//
+// 先批量停止并等待进程进入STOPPED/EXITED/FATAL，再通过 startBatch 启动并等待进入RUNNING，
+// 停止和启动分别最长等待Timeout。通过supervisorctl停止和启动时由supervisorctl等待。
+func (pc *ProcessController) restartBatch(ctx context.Context, names []string, indices []int, errs []error) {
+	// 未在运行的进程直接启动
+	stopErrs, viaCommand := pc.multicallAction(ctx, "stop", names, indices)
+	var toStart []int
+	for _, i := range indices {
+		if err := stopErrs[i]; err != nil && !errors.Is(err, ErrNotRunning) {
+			errs[i] = fmt.Errorf("停止进程失败: %w", err)
+			continue
+		}
+		toStart = append(toStart, i)
+	}
+	if !viaCommand {
+		toStart = pc.waitForProcesses(ctx, names, toStart, errs, "停止", processStopped)
+	}
+	pc.startBatch(ctx, names, toStart, errs)
+}
+
+// startBatch 启动indices中的进程并等待进入RUNNING，结果写入errs
+//
+// 进入FATAL/BACKOFF/EXITED或超过Timeout时返回 *StartError，并附带标准错误日志的最后几行。
+func (pc *ProcessController) startBatch(ctx context.Context, names []string, indices []int, errs []error) {
+	startErrs, viaCommand := pc.multicallAction(ctx, "start", names, indices)
+	var starting []int
+	for _, i := range indices {
+		if startErrs[i] != nil {
+			errs[i] = startErrs[i]
+			continue
+		}
+		starting = append(starting, i)
+	}
+	if viaCommand {
+		return
+	}
+
+	pc.waitForProcesses(ctx, names, starting, errs, "启动", processStarted)
+	for _, i := range starting {
+		var startErr *StartError
+		if errors.As(errs[i], &startErr) {
+			startErr.StderrTail = pc.stderrTail(ctx, names[i])
+		}
+	}
+}
+
+// stopBatch 停止indices中的进程并等待进入STOPPED/EXITED/FATAL，结果写入errs
+func (pc *ProcessController) stopBatch(ctx context.Context, names []string, indices []int, errs []error) {
+	stopErrs, viaCommand := pc.multicallAction(ctx, "stop", names, indices)
+	var stopping []int
+	for _, i := range indices {
+		if stopErrs[i] != nil {
+			errs[i] = stopErrs[i]
+			continue
+		}
+		stopping = append(stopping, i)
+	}
+	if !viaCommand {
+		pc.waitForProcesses(ctx, names, stopping, errs, "停止", processStopped)
+	}
+}
+
+// processStopped 进程已停止时结束等待
+func processStopped(info ProcessInfoRPC) (bool, error) {
+	switch info.State {
+	case ProcessStateStopped, ProcessStateExited, ProcessStateFatal:
+		return true, nil
+	}
+	return false, nil
+}
+
+// processStarted 进程进入RUNNING时结束等待，进入失败状态时返回 *StartError
+func processStarted(info ProcessInfoRPC) (bool, error) {
+	switch info.State {
+	case ProcessStateRunning:
+		return true, nil
+	case ProcessStateFatal, ProcessStateBackoff, ProcessStateExited:
+		return true, &StartError{State: info.StateName, SpawnErr: info.SpawnErr}
+	}
+	return false, nil
+}
+
+// waitForProcesses 轮询进程状态直到indices中的每个进程满足check，返回成功完成的进程
+//
+// check返回done为true时该进程结束等待，err非nil时记录到errs。
+// 超过Timeout时启动阶段返回 *StartError，其他阶段返回超时错误。
+func (pc *ProcessController) waitForProcesses(ctx context.Context, names []string, indices []int, errs []error, phase string,
+	check func(ProcessInfoRPC) (bool, error)) []int {
+	ctx, cancel := context.WithTimeout(ctx, pc.Timeout)
+	defer cancel()
+
+	pending := append([]int(nil), indices...)
+	var done []int
+	last := make(map[int]ProcessInfoRPC)
+	for len(pending) > 0 {
+		infos, err := pc.client.GetAllProcessInfo(ctx)
+		if err != nil && ctx.Err() == nil {
+			for _, i := range pending {
+				errs[i] = fmt.Errorf("等待进程%s失败: %w", phase, err)
+			}
+			return done
+		}
+
+		var next []int
+		for _, i := range pending {
+			info, ok := findProcessInfo(infos, names[i])
+			if !ok {
+				next = append(next, i)
+				continue
+			}
+			last[i] = info
+			finished, err := check(info)
+			switch {
+			case !finished:
+				next = append(next, i)
+			case err != nil:
+				errs[i] = err
+			default:
+				done = append(done, i)
+			}
+		}
+		pending = next
+		if len(pending) == 0 {
+			return done
+		}
+
+		select {
+		case <-time.After(pc.PollInterval):
+			continue
+		case <-ctx.Done():
+		}
+		for _, i := range pending {
+			switch {
+			case errors.Is(ctx.Err(), context.Canceled):
+				errs[i] = fmt.Errorf("等待进程%s失败: %w", phase, ctx.Err())
+			case phase == "启动":
+				errs[i] = &StartError{State: last[i].StateName, SpawnErr: last[i].SpawnErr, Timeout: pc.Timeout}
+			default:
+				errs[i] = fmt.Errorf("等待进程%s超时 (%s)，当前状态: %s", phase, pc.Timeout, last[i].StateName)
+			}
+		}
+		return done
+	}
+	return done
+}
+
+// findProcessInfo 按完整进程名查找进程信息；只有进程名、没有组名时按 name:name 查找
+func findProcessInfo(infos []ProcessInfoRPC, name string) (ProcessInfoRPC, bool) {
+	for _, info := range infos {
+		if full := info.FullName(); full == name || full == processFullName(name, name) {
+			return info, true
+		}
+	}
+	return ProcessInfoRPC{}, false
+}
+
+// stderrTail 读取进程标准错误日志的最后几行；stderr重定向到stdout时读取标准输出日志
+func (pc *ProcessController) stderrTail(ctx context.Context, name string) []string {
+	tail, err := pc.client.TailProcessLog(ctx, name, LogStderr, 0, stderrTailBytes)
+	if errors.Is(err, ErrNoFile) {
+		tail, err = pc.client.TailProcessLog(ctx, name, LogStdout, 0, stderrTailBytes)
+	}
+	if err != nil {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimRight(tail.Bytes, "\n"), "\n")
+	if len(tail.Bytes) >= stderrTailBytes {
+		// 读取的内容从某一行中间开始
+		lines = lines[1:]
+	}
+	var result []string
+	for _, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			result = append(result, strings.TrimRight(line, "\r"))
+		}
+	}
+	if len(result) > stderrTailLines {
+		result = result[len(result)-stderrTailLines:]
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy RPC调用遇到暂时性故障时的重试策略
+type RetryPolicy struct {
+	MaxAttempts        int           // 最大尝试次数（包括第一次），小于等于1表示不重试
+	BaseDelay          time.Duration // 第一次重试前的基础等待时间，之后按指数增长
+	MaxDelay           time.Duration // 单次等待时间的上限
+	RetryNonIdempotent bool          // 是否允许重试会改变状态的调用（如startProcess）
+}
+
+// DefaultRetryPolicy 适用于supervisord刚重启、代理暂时不可用等场景的默认策略
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+// HTTPError 表示非200的HTTP响应
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error 实现error接口
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP错误: %d, %s", e.StatusCode, e.Body)
+}
+
+// idempotentMethods 只读、可以安全重试的方法
+var idempotentMethods = map[string]bool{
+	"system.listMethods":              true,
+	"system.methodHelp":               true,
+	"system.methodSignature":          true,
+	"supervisor.getAPIVersion":        true,
+	"supervisor.getVersion":           true,
+	"supervisor.getSupervisorVersion": true,
+	"supervisor.getIdentification":    true,
+	"supervisor.getState":             true,
+	"supervisor.getPID":               true,
+	"supervisor.readLog":              true,
+	"supervisor.readMainLog":          true,
+	"supervisor.getProcessInfo":       true,
+	"supervisor.getAllProcessInfo":    true,
+	"supervisor.getAllConfigInfo":     true,
+	"supervisor.readProcessLog":       true,
+	"supervisor.readProcessStdoutLog": true,
+	"supervisor.readProcessStderrLog": true,
+	"supervisor.tailProcessLog":       true,
+	"supervisor.tailProcessStdoutLog": true,
+	"supervisor.tailProcessStderrLog": true,
+}
+
+// SetRetryPolicy 设置客户端的重试策略
+func (rc *RPCClient) SetRetryPolicy(policy RetryPolicy) {
+	rc.retry = policy
+}
+
+// maxAttempts 返回该调用允许的最大尝试次数
+func (p RetryPolicy) maxAttempts(method string, params []interface{}) int {
+	if p.MaxAttempts <= 1 {
+		return 1
+	}
+	if p.RetryNonIdempotent || isIdempotentCall(method, params) {
+		return p.MaxAttempts
+	}
+	return 1
+}
+
+// backoff 计算第attempt次失败后的等待时间（指数退避，带随机抖动）
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || delay < p.MaxDelay); i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	// 在[delay/2, delay]之间随机，避免多个客户端同时重试
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// isIdempotentCall 判断调用是否可以安全重试，multicall要求其中所有调用都是只读的
+func isIdempotentCall(method string, params []interface{}) bool {
+	if method != "system.multicall" {
+		return idempotentMethods[method]
+	}
+	if len(params) != 1 {
+		return false
+	}
+	calls, ok := params[0].([]MulticallCall)
+	if !ok {
+		return false
+	}
+	for _, call := range calls {
+		if !idempotentMethods[call.Method] {
+			return false
+		}
+	}
+	return true
+}
+
+// isRetryable 判断错误是否为值得重试的暂时性故障
+//
+// 连接被拒绝/重置、Unix套接字尚未创建以及代理返回的502/503/504可以重试；
+// fault、认证失败和调用方取消不会重试。
+func isRetryable(err error) bool {
+	if err == nil || IsFault(err) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ENOENT) {
+		return true
+	}
+	// 部分平台上拨号错误没有包装errno
+	return strings.Contains(err.Error(), "connection refused")
+}
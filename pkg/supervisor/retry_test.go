@@ -0,0 +1,102 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFlakyServer 前failures次请求返回status，之后由模拟supervisord处理
+func newFlakyServer(t *testing.T, failures int32, status int) (*RPCClient, *int32) {
+	fs := &fakeSupervisor{t: t, handlers: make(map[string]fakeHandler)}
+	fs.result("supervisor.getPID", 99)
+	fs.result("supervisor.startProcess", true)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+		fs.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewRPCClient(server.URL+"/RPC2", "", "")
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+	return client, &requests
+}
+
+// TestRetry_IdempotentRead 测试只读调用在503后重试成功
+func TestRetry_IdempotentRead(t *testing.T) {
+	client, requests := newFlakyServer(t, 2, http.StatusServiceUnavailable)
+
+	pid, err := client.GetPID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 99, pid)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+// TestRetry_NonIdempotentNotRetried 测试非幂等调用默认不重试，显式允许后才重试
+func TestRetry_NonIdempotentNotRetried(t *testing.T) {
+	client, requests := newFlakyServer(t, 1, http.StatusBadGateway)
+
+	err := client.StartProcess(context.Background(), "web", true)
+	var httpErr *HTTPError
+	require.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusBadGateway, httpErr.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+
+	client, requests = newFlakyServer(t, 1, http.StatusBadGateway)
+	client.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, RetryNonIdempotent: true})
+	require.NoError(t, client.StartProcess(context.Background(), "web", true))
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+// TestRetry_AuthFailureNotRetried 测试认证失败不重试
+func TestRetry_AuthFailureNotRetried(t *testing.T) {
+	client, requests := newFlakyServer(t, 5, http.StatusUnauthorized)
+
+	_, err := client.GetPID(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+// TestIsRetryable 测试可重试错误的判断
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, isRetryable(fmt.Errorf("请求失败: %w", syscall.ECONNREFUSED)))
+	assert.True(t, isRetryable(&HTTPError{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, isRetryable(&HTTPError{StatusCode: http.StatusForbidden}))
+	assert.False(t, isRetryable(&Fault{Code: FaultFailed}))
+	assert.False(t, isRetryable(context.Canceled))
+}
+
+// TestIsIdempotentCall 测试multicall的幂等性判断
+func TestIsIdempotentCall(t *testing.T) {
+	reads := []MulticallCall{{Method: "supervisor.getProcessInfo"}, {Method: "supervisor.getState"}}
+	writes := []MulticallCall{{Method: "supervisor.getProcessInfo"}, {Method: "supervisor.stopProcess"}}
+
+	assert.True(t, isIdempotentCall("system.multicall", []interface{}{reads}))
+	assert.False(t, isIdempotentCall("system.multicall", []interface{}{writes}))
+	assert.False(t, isIdempotentCall("supervisor.startProcess", nil))
+}
+
+// TestRetryPolicy_Backoff 测试退避时间随次数增长且不超过上限
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for i := 0; i < 20; i++ {
+		first := policy.backoff(1)
+		assert.True(t, first >= 50*time.Millisecond && first <= 100*time.Millisecond, first)
+		capped := policy.backoff(10)
+		assert.True(t, capped >= 150*time.Millisecond && capped <= 300*time.Millisecond, capped)
+	}
+}
@@ -0,0 +1,134 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrRollingAborted 滚动重启中之前的批次失败，剩余进程没有重启
+var ErrRollingAborted = errors.New("之前的批次失败，未重启")
+
+// RollingOptions 滚动重启选项
+type RollingOptions struct {
+	BatchSize int           // 每批重启的进程数，小于1时按1处理
+	Pause     time.Duration // 每批启动后进程需要保持RUNNING的时间，通过后才重启下一批
+}
+
+// RollingBatch 滚动重启中一个批次的结果
+type RollingBatch struct {
+	Number int      // 批次序号，从1开始
+	Total  int      // 批次总数
+	Names  []string // 本批次的进程
+	Errs   []error  // 与Names一一对应
+}
+
+// RollingRestart 分批重启进程，每批重启后等待其中的进程保持RUNNING达到Pause
+//
+// 某个批次有进程失败时停止，剩余进程的错误为 ErrRollingAborted。
+// 每个批次完成后调用report，返回与names一一对应的错误。
+func (pc *ProcessController) RollingRestart(ctx context.Context, names []string, opts RollingOptions, report func(RollingBatch)) []error {
+	size := max(opts.BatchSize, 1)
+	total := (len(names) + size - 1) / size
+	errs := make([]error, len(names))
+
+	for start, number := 0, 1; start < len(names); start, number = start+size, number+1 {
+		end := min(start+size, len(names))
+		batch := RollingBatch{Number: number, Total: total, Names: names[start:end]}
+		batch.Errs = pc.ControlBatch(ctx, "restart", batch.Names)
+		pc.watchRunning(ctx, batch.Names, batch.Errs, opts.Pause)
+		copy(errs[start:end], batch.Errs)
+		if report != nil {
+			report(batch)
+		}
+
+		if !allSucceeded(batch.Errs) {
+			for i := end; i < len(names); i++ {
+				errs[i] = ErrRollingAborted
+			}
+			break
+		}
+	}
+	return errs
+}
+
+// watchRunning 在window时间内轮询进程状态，errs中还没有错误的进程离开RUNNING或不存在时记录错误
+// window为0时只检查一次；没有RPC客户端时无法检查
+func (pc *ProcessController) watchRunning(ctx context.Context, names []string, errs []error, window time.Duration) {
+	if pc.client == nil {
+		return
+	}
+
+	deadline := time.Now().Add(window)
+	for {
+		pending := false
+		infos, err := pc.client.GetAllProcessInfo(ctx)
+		if err != nil {
+			for i := range names {
+				if errs[i] == nil {
+					errs[i] = fmt.Errorf("检查进程状态失败: %w", err)
+				}
+			}
+			return
+		}
+		states := make(map[string]ProcessInfoRPC, len(infos))
+		for _, info := range infos {
+			states[info.FullName()] = info
+		}
+		for i, name := range names {
+			if errs[i] != nil {
+				continue
+			}
+			info, ok := states[name]
+			if !ok {
+				// 只有进程名、没有组名时按 name:name 查找
+				info, ok = states[processFullName(name, name)]
+			}
+			if !ok {
+				errs[i] = errors.New("进程不存在")
+				continue
+			}
+			if info.State != ProcessStateRunning {
+				errs[i] = processStateError(info)
+				continue
+			}
+			pending = true
+		}
+
+		if !pending || !time.Now().Before(deadline) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			for i := range names {
+				if errs[i] == nil {
+					errs[i] = fmt.Errorf("等待进程保持运行失败: %w", ctx.Err())
+				}
+			}
+			return
+		case <-time.After(min(pc.PollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// processStateError 描述进程未处于RUNNING的原因
+func processStateError(info ProcessInfoRPC) error {
+	if info.SpawnErr != "" {
+		return fmt.Errorf("进程处于%s状态: %s", info.StateName, info.SpawnErr)
+	}
+	if info.Description != "" {
+		return fmt.Errorf("进程处于%s状态: %s", info.StateName, info.Description)
+	}
+	return fmt.Errorf("进程处于%s状态", info.StateName)
+}
+
+// allSucceeded 检查是否所有操作都成功
+func allSucceeded(errs []error) bool {
+	for _, err := range errs {
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
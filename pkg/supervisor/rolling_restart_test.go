@@ -0,0 +1,70 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRollingRestart 测试分批重启并在批次失败后停止
+func TestRollingRestart(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	table := newFakeProcessTable(fs, "api:a", "api:b", "api:c", "api:d", "api:e")
+	table.get("api:c").startState = ProcessStateBackoff
+	table.get("api:c").spawnErr = "Exited too quickly (process log may have details)"
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = 5 * time.Millisecond
+	var batches []RollingBatch
+	names := []string{"api:a", "api:b", "api:c", "api:d", "api:e"}
+	errs := ctrl.RollingRestart(context.Background(), names, RollingOptions{BatchSize: 2, Pause: 20 * time.Millisecond},
+		func(batch RollingBatch) { batches = append(batches, batch) })
+
+	require.Len(t, batches, 2)
+	assert.Equal(t, 1, batches[0].Number)
+	assert.Equal(t, 3, batches[0].Total)
+	assert.Equal(t, []string{"api:c", "api:d"}, batches[1].Names)
+
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	require.Error(t, errs[2])
+	assert.Contains(t, errs[2].Error(), "BACKOFF")
+	assert.Contains(t, errs[2].Error(), "Exited too quickly")
+	assert.NoError(t, errs[3])
+	assert.True(t, errors.Is(errs[4], ErrRollingAborted))
+	assert.Equal(t, []string{"api:a", "api:b", "api:c", "api:d"}, table.startedNames())
+}
+
+// TestRollingRestart_StartFailure 测试启动失败的批次不再等待观察期
+func TestRollingRestart_StartFailure(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	newFakeProcessTable(fs, "api:a", "api:b")
+	fs.handle("supervisor.startProcess", func([]interface{}) (interface{}, error) {
+		return nil, &Fault{Code: FaultSpawnError, String: "SPAWN_ERROR: api:a"}
+	})
+
+	begin := time.Now()
+	errs := NewProcessController(client).RollingRestart(context.Background(), []string{"api:a", "api:b"},
+		RollingOptions{BatchSize: 1, Pause: time.Minute}, nil)
+	assert.True(t, errors.Is(errs[0], ErrSpawnError))
+	assert.True(t, errors.Is(errs[1], ErrRollingAborted))
+	assert.Less(t, time.Since(begin), 10*time.Second)
+}
+
+// TestWatchRunning_ProcessMissing 测试进程不在进程列表中时不会被当作保持运行
+func TestWatchRunning_ProcessMissing(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	newFakeProcessTable(fs, "api:a")
+
+	ctrl := NewProcessController(client)
+	ctrl.PollInterval = 5 * time.Millisecond
+	errs := make([]error, 2)
+	ctrl.watchRunning(context.Background(), []string{"api:a", "api:gone"}, errs, 20*time.Millisecond)
+	assert.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	assert.Contains(t, errs[1].Error(), "进程不存在")
+}
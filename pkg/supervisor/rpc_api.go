@@ -0,0 +1,423 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+)
+
+// 本文件提供Supervisor XML-RPC API（supervisor.* 和 system.* 命名空间）的类型化封装。
+// 所有方法在出错时返回 *Fault（Supervisor返回的fault）或传输层错误。
+// ctx 取消时请求立即中断；ctx 没有截止时间时使用客户端的默认超时。
+
+// SupervisorState supervisord自身的状态
+type SupervisorState struct {
+	Code int    `xml:"statecode"`
+	Name string `xml:"statename"`
+}
+
+// supervisord状态码
+const (
+	SupervisorStateFatal      = 2
+	SupervisorStateRunning    = 1
+	SupervisorStateRestarting = 0
+	SupervisorStateShutdown   = -1
+)
+
+// ProcessStatus 批量操作（组操作、全部操作）中单个进程的执行结果
+type ProcessStatus struct {
+	Name        string `xml:"name"`
+	Group       string `xml:"group"`
+	Status      int    `xml:"status"`
+	Description string `xml:"description"`
+}
+
+// FullName 返回 group:name 形式的完整进程名
+func (ps ProcessStatus) FullName() string {
+	return processFullName(ps.Group, ps.Name)
+}
+
+// Err 将执行结果转换为错误，成功时返回nil
+func (ps ProcessStatus) Err() error {
+	if ps.Status == FaultSuccess {
+		return nil
+	}
+	return &Fault{Code: ps.Status, String: ps.Description}
+}
+
+// FullName 返回 group:name 形式的完整进程名
+func (info ProcessInfoRPC) FullName() string {
+	return processFullName(info.Group, info.Name)
+}
+
+// processFullName 生成完整进程名称 (group:name)
+func processFullName(group, name string) string {
+	if group != "" && name != "" {
+		return group + ":" + name
+	}
+	return name
+}
+
+// LogTail tail*Log 调用的结果
+type LogTail struct {
+	Bytes    string // 读取到的日志内容
+	Offset   int    // 下一次读取应使用的偏移量
+	Overflow bool   // 日志增长超过请求长度，部分内容被跳过
+}
+
+// ConfigChanges reloadConfig 返回的配置变更
+type ConfigChanges struct {
+	Added   []string
+	Changed []string
+	Removed []string
+}
+
+// ConfigInfo getAllConfigInfo 返回的单个进程配置
+type ConfigInfo struct {
+	Name                  string `xml:"name"`
+	Group                 string `xml:"group"`
+	Command               string `xml:"command"`
+	Directory             string `xml:"directory"`
+	UID                   int    `xml:"uid"`
+	Autostart             bool   `xml:"autostart"`
+	Inuse                 bool   `xml:"inuse"`
+	KillAsGroup           bool   `xml:"killasgroup"`
+	RedirectStderr        bool   `xml:"redirect_stderr"`
+	ExitCodes             []int  `xml:"exitcodes"`
+	StartSecs             int    `xml:"startsecs"`
+	StartRetries          int    `xml:"startretries"`
+	StopSignal            int    `xml:"stopsignal"`
+	StopWaitSecs          int    `xml:"stopwaitsecs"`
+	ProcessPrio           int    `xml:"process_prio"`
+	GroupPrio             int    `xml:"group_prio"`
+	StdoutLogfile         string `xml:"stdout_logfile"`
+	StdoutLogfileMaxbytes int    `xml:"stdout_logfile_maxbytes"`
+	StdoutLogfileBackups  int    `xml:"stdout_logfile_backups"`
+	StderrLogfile         string `xml:"stderr_logfile"`
+	StderrLogfileMaxbytes int    `xml:"stderr_logfile_maxbytes"`
+	StderrLogfileBackups  int    `xml:"stderr_logfile_backups"`
+	ServerURL             string `xml:"serverurl"`
+}
+
+// FullName 返回 group:name 形式的完整进程名
+func (ci ConfigInfo) FullName() string {
+	return processFullName(ci.Group, ci.Name)
+}
+
+// ===== system.* =====
+
+// ListMethods 列出服务端支持的所有方法
+func (rc *RPCClient) ListMethods(ctx context.Context) ([]string, error) {
+	var methods []string
+	err := rc.call(ctx, "system.listMethods", nil, &methods)
+	return methods, err
+}
+
+// MethodHelp 获取方法的帮助文本
+func (rc *RPCClient) MethodHelp(ctx context.Context, method string) (string, error) {
+	var help string
+	err := rc.call(ctx, "system.methodHelp", []interface{}{method}, &help)
+	return help, err
+}
+
+// MethodSignature 获取方法签名
+func (rc *RPCClient) MethodSignature(ctx context.Context, method string) ([][]string, error) {
+	var signatures [][]string
+	err := rc.call(ctx, "system.methodSignature", []interface{}{method}, &signatures)
+	return signatures, err
+}
+
+// ===== supervisord 自身 =====
+
+// GetAPIVersion 获取RPC API版本
+func (rc *RPCClient) GetAPIVersion(ctx context.Context) (string, error) {
+	var version string
+	err := rc.call(ctx, "supervisor.getAPIVersion", nil, &version)
+	return version, err
+}
+
+// GetSupervisorVersion 获取supervisor软件包版本
+func (rc *RPCClient) GetSupervisorVersion(ctx context.Context) (string, error) {
+	var version string
+	err := rc.call(ctx, "supervisor.getSupervisorVersion", nil, &version)
+	return version, err
+}
+
+// GetIdentification 获取supervisord的标识字符串
+func (rc *RPCClient) GetIdentification(ctx context.Context) (string, error) {
+	var identification string
+	err := rc.call(ctx, "supervisor.getIdentification", nil, &identification)
+	return identification, err
+}
+
+// GetState 获取supervisord的运行状态
+func (rc *RPCClient) GetState(ctx context.Context) (SupervisorState, error) {
+	var state SupervisorState
+	err := rc.call(ctx, "supervisor.getState", nil, &state)
+	return state, err
+}
+
+// GetPID 获取supervisord的PID
+func (rc *RPCClient) GetPID(ctx context.Context) (int, error) {
+	var pid int
+	err := rc.call(ctx, "supervisor.getPID", nil, &pid)
+	return pid, err
+}
+
+// ReadLog 读取supervisord主日志
+func (rc *RPCClient) ReadLog(ctx context.Context, offset, length int) (string, error) {
+	var log string
+	err := rc.call(ctx, "supervisor.readLog", []interface{}{offset, length}, &log)
+	return log, err
+}
+
+// ClearLog 清空supervisord主日志
+func (rc *RPCClient) ClearLog(ctx context.Context) error {
+	return rc.call(ctx, "supervisor.clearLog", nil, nil)
+}
+
+// Shutdown 关闭supervisord
+func (rc *RPCClient) Shutdown(ctx context.Context) error {
+	return rc.call(ctx, "supervisor.shutdown", nil, nil)
+}
+
+// Restart 重启supervisord
+func (rc *RPCClient) Restart(ctx context.Context) error {
+	return rc.call(ctx, "supervisor.restart", nil, nil)
+}
+
+// ===== 进程信息 =====
+
+// GetProcessInfo 获取单个进程信息，name 为 group:name 形式
+func (rc *RPCClient) GetProcessInfo(ctx context.Context, name string) (ProcessInfoRPC, error) {
+	var info ProcessInfoRPC
+	err := rc.call(ctx, "supervisor.getProcessInfo", []interface{}{name}, &info)
+	return info, err
+}
+
+// GetAllProcessInfo 获取所有进程的原始信息
+func (rc *RPCClient) GetAllProcessInfo(ctx context.Context) ([]ProcessInfoRPC, error) {
+	var infos []ProcessInfoRPC
+	err := rc.call(ctx, "supervisor.getAllProcessInfo", nil, &infos)
+	return infos, err
+}
+
+// GetAllConfigInfo 获取所有进程的配置信息
+func (rc *RPCClient) GetAllConfigInfo(ctx context.Context) ([]ConfigInfo, error) {
+	var infos []ConfigInfo
+	err := rc.call(ctx, "supervisor.getAllConfigInfo", nil, &infos)
+	return infos, err
+}
+
+// ===== 进程控制 =====
+
+// StartProcess 启动进程，wait 为 true 时等待进程完全启动
+func (rc *RPCClient) StartProcess(ctx context.Context, name string, wait bool) error {
+	return rc.call(ctx, "supervisor.startProcess", []interface{}{name, wait}, nil)
+}
+
+// StopProcess 停止进程，wait 为 true 时等待进程完全停止
+func (rc *RPCClient) StopProcess(ctx context.Context, name string, wait bool) error {
+	return rc.call(ctx, "supervisor.stopProcess", []interface{}{name, wait}, nil)
+}
+
+// StartProcessGroup 启动进程组中的所有进程
+func (rc *RPCClient) StartProcessGroup(ctx context.Context, name string, wait bool) ([]ProcessStatus, error) {
+	var results []ProcessStatus
+	err := rc.call(ctx, "supervisor.startProcessGroup", []interface{}{name, wait}, &results)
+	return results, err
+}
+
+// StopProcessGroup 停止进程组中的所有进程
+func (rc *RPCClient) StopProcessGroup(ctx context.Context, name string, wait bool) ([]ProcessStatus, error) {
+	var results []ProcessStatus
+	err := rc.call(ctx, "supervisor.stopProcessGroup", []interface{}{name, wait}, &results)
+	return results, err
+}
+
+// StartAllProcesses 启动所有进程
+func (rc *RPCClient) StartAllProcesses(ctx context.Context, wait bool) ([]ProcessStatus, error) {
+	var results []ProcessStatus
+	err := rc.call(ctx, "supervisor.startAllProcesses", []interface{}{wait}, &results)
+	return results, err
+}
+
+// StopAllProcesses 停止所有进程
+func (rc *RPCClient) StopAllProcesses(ctx context.Context, wait bool) ([]ProcessStatus, error) {
+	var results []ProcessStatus
+	err := rc.call(ctx, "supervisor.stopAllProcesses", []interface{}{wait}, &results)
+	return results, err
+}
+
+// SignalProcess 向进程发送信号，signal 可以是信号名（如 HUP）或数字
+func (rc *RPCClient) SignalProcess(ctx context.Context, name, signal string) error {
+	return rc.call(ctx, "supervisor.signalProcess", []interface{}{name, signal}, nil)
+}
+
+// SignalProcessGroup 向进程组中的所有进程发送信号
+func (rc *RPCClient) SignalProcessGroup(ctx context.Context, name, signal string) ([]ProcessStatus, error) {
+	var results []ProcessStatus
+	err := rc.call(ctx, "supervisor.signalProcessGroup", []interface{}{name, signal}, &results)
+	return results, err
+}
+
+// SignalAllProcesses 向所有进程发送信号
+func (rc *RPCClient) SignalAllProcesses(ctx context.Context, signal string) ([]ProcessStatus, error) {
+	var results []ProcessStatus
+	err := rc.call(ctx, "supervisor.signalAllProcesses", []interface{}{signal}, &results)
+	return results, err
+}
+
+// SendProcessStdin 向进程的标准输入写入数据
+func (rc *RPCClient) SendProcessStdin(ctx context.Context, name, chars string) error {
+	return rc.call(ctx, "supervisor.sendProcessStdin", []interface{}{name, chars}, nil)
+}
+
+// ===== 配置管理 =====
+
+// ReloadConfig 重新读取配置文件并返回变更（不会应用变更）
+func (rc *RPCClient) ReloadConfig(ctx context.Context) (ConfigChanges, error) {
+	// 返回值格式为 [[added, changed, removed]]
+	var raw [][][]string
+	if err := rc.call(ctx, "supervisor.reloadConfig", nil, &raw); err != nil {
+		return ConfigChanges{}, err
+	}
+	if len(raw) != 1 || len(raw[0]) != 3 {
+		return ConfigChanges{}, fmt.Errorf("reloadConfig返回格式无效")
+	}
+	return ConfigChanges{Added: raw[0][0], Changed: raw[0][1], Removed: raw[0][2]}, nil
+}
+
+// AddProcessGroup 添加配置中新增的进程组
+func (rc *RPCClient) AddProcessGroup(ctx context.Context, name string) error {
+	return rc.call(ctx, "supervisor.addProcessGroup", []interface{}{name}, nil)
+}
+
+// RemoveProcessGroup 移除已停止的进程组
+func (rc *RPCClient) RemoveProcessGroup(ctx context.Context, name string) error {
+	return rc.call(ctx, "supervisor.removeProcessGroup", []interface{}{name}, nil)
+}
+
+// ===== 进程日志 =====
+
+// ReadProcessStdoutLog 从offset开始读取length字节的标准输出日志
+func (rc *RPCClient) ReadProcessStdoutLog(ctx context.Context, name string, offset, length int) (string, error) {
+	var log string
+	err := rc.call(ctx, "supervisor.readProcessStdoutLog", []interface{}{name, offset, length}, &log)
+	return log, err
+}
+
+// ReadProcessStderrLog 从offset开始读取length字节的标准错误日志
+func (rc *RPCClient) ReadProcessStderrLog(ctx context.Context, name string, offset, length int) (string, error) {
+	var log string
+	err := rc.call(ctx, "supervisor.readProcessStderrLog", []interface{}{name, offset, length}, &log)
+	return log, err
+}
+
+// TailProcessStdoutLog 按offset/overflow协议读取标准输出日志尾部
+func (rc *RPCClient) TailProcessStdoutLog(ctx context.Context, name string, offset, length int) (LogTail, error) {
+	return rc.tailProcessLog(ctx, "supervisor.tailProcessStdoutLog", name, offset, length)
+}
+
+// TailProcessStderrLog 按offset/overflow协议读取标准错误日志尾部
+func (rc *RPCClient) TailProcessStderrLog(ctx context.Context, name string, offset, length int) (LogTail, error) {
+	return rc.tailProcessLog(ctx, "supervisor.tailProcessStderrLog", name, offset, length)
+}
+
+// tailProcessLog 调用tail*Log并解析 [bytes, offset, overflow] 返回值
+func (rc *RPCClient) tailProcessLog(ctx context.Context, method, name string, offset, length int) (LogTail, error) {
+	var raw []interface{}
+	if err := rc.call(ctx, method, []interface{}{name, offset, length}, &raw); err != nil {
+		return LogTail{}, err
+	}
+	return decodeLogTail(raw)
+}
+
+// decodeLogTail 解析tail*Log返回的数组
+func decodeLogTail(raw []interface{}) (LogTail, error) {
+	var tail LogTail
+	if len(raw) != 3 {
+		return tail, fmt.Errorf("tail日志返回格式无效")
+	}
+	if err := DecodeValue(raw[0], &tail.Bytes); err != nil {
+		return tail, err
+	}
+	if err := DecodeValue(raw[1], &tail.Offset); err != nil {
+		return tail, err
+	}
+	if err := DecodeValue(raw[2], &tail.Overflow); err != nil {
+		return tail, err
+	}
+	return tail, nil
+}
+
+// ClearProcessLogs 清空进程的标准输出和标准错误日志
+func (rc *RPCClient) ClearProcessLogs(ctx context.Context, name string) error {
+	return rc.call(ctx, "supervisor.clearProcessLogs", []interface{}{name}, nil)
+}
+
+// ClearAllProcessLogs 清空所有进程的日志
+func (rc *RPCClient) ClearAllProcessLogs(ctx context.Context) ([]ProcessStatus, error) {
+	var results []ProcessStatus
+	err := rc.call(ctx, "supervisor.clearAllProcessLogs", nil, &results)
+	return results, err
+}
+
+// ===== system.multicall =====
+
+// MulticallCall system.multicall 中的单个调用
+type MulticallCall struct {
+	Method string        `xml:"methodName"`
+	Params []interface{} `xml:"params"`
+}
+
+// MulticallResult system.multicall 中单个调用的结果
+type MulticallResult struct {
+	Value interface{} // 通用返回值，可用 Decode 解码
+	Err   error       // 调用失败时为 *Fault
+}
+
+// Decode 将返回值解码到dst中，调用失败时返回对应的错误
+func (r MulticallResult) Decode(dst interface{}) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	return DecodeValue(r.Value, dst)
+}
+
+// Multicall 在一次请求中执行多个调用，结果与calls一一对应
+//
+// 返回的error只表示整个请求失败；单个调用的失败记录在对应结果的Err中。
+func (rc *RPCClient) Multicall(ctx context.Context, calls []MulticallCall) ([]MulticallResult, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	var raw []interface{}
+	if err := rc.call(ctx, "system.multicall", []interface{}{calls}, &raw); err != nil {
+		return nil, err
+	}
+	if len(raw) != len(calls) {
+		return nil, fmt.Errorf("multicall返回结果数量不匹配: 期望%d，实际%d", len(calls), len(raw))
+	}
+
+	results := make([]MulticallResult, len(raw))
+	for i, item := range raw {
+		switch v := item.(type) {
+		case []interface{}:
+			// 成功的调用返回只包含一个元素的数组
+			if len(v) != 1 {
+				return nil, fmt.Errorf("multicall第%d个结果格式无效", i+1)
+			}
+			results[i].Value = v[0]
+		case map[string]interface{}:
+			fault := &Fault{}
+			if err := DecodeValue(v, fault); err != nil {
+				return nil, fmt.Errorf("multicall第%d个结果格式无效: %v", i+1, err)
+			}
+			results[i].Err = fault
+		default:
+			return nil, fmt.Errorf("multicall第%d个结果格式无效", i+1)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,198 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRPCClient_GetState 测试获取supervisord状态
+func TestRPCClient_GetState(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.getState", map[string]interface{}{"statecode": 1, "statename": "RUNNING"})
+	fs.result("supervisor.getPID", 4242)
+
+	state, err := client.GetState(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, SupervisorState{Code: SupervisorStateRunning, Name: "RUNNING"}, state)
+
+	pid, err := client.GetPID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 4242, pid)
+}
+
+// TestRPCClient_StartProcess 测试启动进程时传递的参数
+func TestRPCClient_StartProcess(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	var got []interface{}
+	fs.handle("supervisor.startProcess", func(params []interface{}) (interface{}, error) {
+		got = params
+		return true, nil
+	})
+
+	require.NoError(t, client.StartProcess(context.Background(), "web:web_00", true))
+	assert.Equal(t, []interface{}{"web:web_00", true}, got)
+}
+
+// TestRPCClient_Fault 测试fault以 *Fault 返回
+func TestRPCClient_Fault(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.handle("supervisor.stopProcess", func([]interface{}) (interface{}, error) {
+		return nil, &Fault{Code: 70, String: "NOT_RUNNING: web:web_00"}
+	})
+
+	err := client.StopProcess(context.Background(), "web:web_00", true)
+	require.Error(t, err)
+	fault, ok := err.(*Fault)
+	require.True(t, ok)
+	assert.Equal(t, 70, fault.Code)
+}
+
+// TestRPCClient_GroupResults 测试组操作返回每个进程的结果
+func TestRPCClient_GroupResults(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.stopProcessGroup", []interface{}{
+		map[string]interface{}{"name": "web_00", "group": "web", "status": 80, "description": "OK"},
+	})
+
+	results, err := client.StopProcessGroup(context.Background(), "web", false)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "web:web_00", results[0].FullName())
+	assert.Equal(t, 80, results[0].Status)
+}
+
+// TestRPCClient_TailProcessStdoutLog 测试tail日志的返回值解析
+func TestRPCClient_TailProcessStdoutLog(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.tailProcessStdoutLog", []interface{}{"line\n", 1024, true})
+
+	tail, err := client.TailProcessStdoutLog(context.Background(), "web:web_00", 0, 1024)
+	require.NoError(t, err)
+	assert.Equal(t, LogTail{Bytes: "line\n", Offset: 1024, Overflow: true}, tail)
+}
+
+// TestRPCClient_ReloadConfig 测试reloadConfig的返回值解析
+func TestRPCClient_ReloadConfig(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.reloadConfig", []interface{}{
+		[]interface{}{[]interface{}{"new"}, []interface{}{"web"}, []interface{}{}},
+	})
+
+	changes, err := client.ReloadConfig(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new"}, changes.Added)
+	assert.Equal(t, []string{"web"}, changes.Changed)
+	assert.Empty(t, changes.Removed)
+}
+
+// TestRPCClient_GetAllConfigInfo 测试配置信息中的nil值
+func TestRPCClient_GetAllConfigInfo(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.getAllConfigInfo", []interface{}{
+		map[string]interface{}{"name": "web_00", "group": "web", "command": "/bin/web",
+			"directory": nil, "uid": nil, "autostart": true, "exitcodes": []interface{}{0}, "stopsignal": 15},
+	})
+
+	infos, err := client.GetAllConfigInfo(context.Background())
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, "web:web_00", infos[0].FullName())
+	assert.Equal(t, "", infos[0].Directory)
+	assert.Equal(t, []int{0}, infos[0].ExitCodes)
+	assert.Equal(t, 15, infos[0].StopSignal)
+}
+
+// TestRPCClient_UnixSocket 测试通过Unix域套接字调用，并携带基本认证
+func TestRPCClient_UnixSocket(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "supervisor.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	fs := &fakeSupervisor{t: t, handlers: make(map[string]fakeHandler)}
+	fs.result("supervisor.getPID", 7)
+	var user, pass string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ = r.BasicAuth()
+		fs.ServeHTTP(w, r)
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	client := NewRPCClient("unix://"+socketPath, "admin", "secret")
+	assert.True(t, client.IsLocal())
+
+	pid, err := client.GetPID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 7, pid)
+	assert.Equal(t, "admin", user)
+	assert.Equal(t, "secret", pass)
+}
+
+// TestRPCClient_Multicall 测试multicall逐个返回结果和fault
+func TestRPCClient_Multicall(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.getPID", 10)
+
+	results, err := client.Multicall(context.Background(), []MulticallCall{
+		{Method: "supervisor.getPID"},
+		{Method: "supervisor.nope", Params: []interface{}{"x"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	var pid int
+	require.NoError(t, results[0].Decode(&pid))
+	assert.Equal(t, 10, pid)
+	assert.True(t, errors.Is(results[1].Err, ErrUnknownMethod))
+}
+
+// TestRPCClient_ContextCancel 测试取消ctx会中断正在进行的调用
+func TestRPCClient_ContextCancel(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	release := make(chan struct{})
+	defer close(release)
+	fs.handle("supervisor.startProcess", func([]interface{}) (interface{}, error) {
+		<-release
+		return true, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	err := client.StartProcess(ctx, "web", true)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+// TestRPCClient_Deadline 测试默认超时和调用方指定的截止时间
+func TestRPCClient_Deadline(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.handle("supervisor.readLog", func([]interface{}) (interface{}, error) {
+		time.Sleep(100 * time.Millisecond)
+		return "log", nil
+	})
+
+	client.SetTimeout(20 * time.Millisecond)
+	_, err := client.ReadLog(context.Background(), 0, 100)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// 调用方指定的更长截止时间优先于默认超时
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	log, err := client.ReadLog(ctx, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, "log", log)
+}
@@ -2,32 +2,68 @@ package supervisor
 
 import (
 	"bytes"
-	"encoding/xml"
+	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"os/exec"
 	"strings"
 	"time"
+
 	"github.com/x1t/sv/pkg/utils"
 )
 
+// unixSocketScheme Unix域套接字地址前缀，与supervisorctl的serverurl语法一致
+const unixSocketScheme = "unix://"
+
+// unixSocketEndpoint 通过Unix域套接字发送请求时使用的HTTP地址
+const unixSocketEndpoint = "http://localhost/RPC2"
+
+// DefaultCallTimeout 调用方的ctx没有截止时间时，单次调用的默认超时
+const DefaultCallTimeout = 10 * time.Second
+
 // RPCClient Supervisor RPC客户端
 type RPCClient struct {
-	host     string
-	username string
-	password string
-	client   *http.Client
+	host       string
+	endpoint   string // 实际发送HTTP请求的地址
+	socketPath string // Unix域套接字路径，为空表示使用TCP
+	username   string
+	password   string
+	timeout    time.Duration // ctx没有截止时间时使用的默认超时
+	retry      RetryPolicy   // 暂时性故障的重试策略，默认不重试
+	client     *http.Client
 }
 
 // NewRPCClient 创建新的Supervisor客户端
+//
+// host 支持 http(s)://host:port/RPC2 和 unix:///path/to/supervisor.sock 两种形式
 func NewRPCClient(host, username, password string) *RPCClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	endpoint := host
+	socketPath := ""
+
+	if strings.HasPrefix(host, unixSocketScheme) {
+		socketPath = strings.TrimPrefix(host, unixSocketScheme)
+		endpoint = unixSocketEndpoint
+		// 所有连接都拨到Unix域套接字，忽略URL中的主机名
+		transport.Proxy = nil
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}
+	}
+
 	return &RPCClient{
-		host:     host,
-		username: username,
-		password: password,
+		host:       host,
+		endpoint:   endpoint,
+		socketPath: socketPath,
+		username:   username,
+		password:   password,
+		timeout:    DefaultCallTimeout,
 		client: &http.Client{
-			Timeout: 10 * time.Second,
+			Transport: transport,
 			CheckRedirect: func(req *http.Request, via []*http.Request) error {
 				// 禁止HTTP重定向以防止SSRF攻击
 				return http.ErrUseLastResponse
@@ -36,54 +72,66 @@ func NewRPCClient(host, username, password string) *RPCClient {
 	}
 }
 
-// call 调用XML-RPC方法
-func (rc *RPCClient) call(method string, params []interface{}) (interface{}, error) {
-	// 构建methodCall
-	call := MethodCall{
-		MethodName: method,
+// IsLocal 判断客户端是否连接的是本机的Supervisor
+func (rc *RPCClient) IsLocal() bool {
+	if rc.socketPath != "" {
+		return true
 	}
+	u, err := url.Parse(rc.host)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// SetTimeout 设置ctx没有截止时间时单次调用的默认超时，0表示不限制
+func (rc *RPCClient) SetTimeout(timeout time.Duration) {
+	rc.timeout = timeout
+}
+
+// call 调用XML-RPC方法，并将返回值解码到result中（result为nil时忽略返回值）
+//
+// 遇到暂时性故障时按重试策略重试，非幂等调用只有在策略允许时才会重试。
+func (rc *RPCClient) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	attempts := rc.retry.maxAttempts(method, params)
+	for attempt := 1; ; attempt++ {
+		err := rc.doCall(ctx, method, params, result)
+		if err == nil || attempt >= attempts || !isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
 
-	if params != nil {
-		for _, param := range params {
-			var value Value
-			switch v := param.(type) {
-			case string:
-				value.String = v
-			case int:
-				value.Int = v
-			case bool:
-				value.Boolean = v
-			case []interface{}:  // 处理数组参数
-				value.Array = ArrayValues{
-					Data: ArrayData{
-						Values: make([]Value, len(v)),
-					},
-				}
-				for i, item := range v {
-					switch iv := item.(type) {
-					case string:
-						value.Array.Data.Values[i] = Value{String: iv}
-					case int:
-						value.Array.Data.Values[i] = Value{Int: iv}
-					case bool:
-						value.Array.Data.Values[i] = Value{Boolean: iv}
-					}
-				}
-			}
-			call.Params = append(call.Params, Param{Value: value})
+		select {
+		case <-time.After(rc.retry.backoff(attempt)):
+		case <-ctx.Done():
+			return err
 		}
 	}
+}
+
+// doCall 执行一次XML-RPC请求
+func (rc *RPCClient) doCall(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	// 调用方没有指定截止时间时使用默认超时
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline && rc.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rc.timeout)
+		defer cancel()
+	}
 
-	// 序列化为XML
-	xmlData, err := xml.Marshal(call)
+	// 编码methodCall
+	xmlData, err := EncodeMethodCall(method, params...)
 	if err != nil {
-		return nil, fmt.Errorf("XML序列化失败: %v", err)
+		return fmt.Errorf("XML序列化失败: %v", err)
 	}
 
 	// 创建HTTP请求
-	req, err := http.NewRequest("POST", rc.host, bytes.NewBuffer(xmlData))
+	req, err := http.NewRequestWithContext(ctx, "POST", rc.endpoint, bytes.NewBuffer(xmlData))
 	if err != nil {
-		return nil, fmt.Errorf("创建请求失败: %v", err)
+		return fmt.Errorf("创建请求失败: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "text/xml")
@@ -97,7 +145,7 @@ func (rc *RPCClient) call(method string, params []interface{}) (interface{}, err
 	// 发送请求
 	resp, err := rc.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("请求失败: %v", err)
+		return fmt.Errorf("请求失败: %w", err)
 	}
 
 	// 确保在所有路径下都关闭响应体
@@ -110,215 +158,92 @@ func (rc *RPCClient) call(method string, params []interface{}) (interface{}, err
 	// 读取响应
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("读取响应失败: %v", err)
+		return fmt.Errorf("读取响应失败: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP错误: %d, %s", resp.StatusCode, string(body))
-	}
-
-	// 为了正确解析响应，我们需要使用EnhancedValue结构
-	// 重新定义MethodResponse使用EnhancedValue
-	response := struct {
-		XMLName xml.Name      `xml:"methodResponse"`
-		Params  []struct {
-			Value EnhancedValue `xml:"param>value"`
-		} `xml:"params"`
-		Fault *struct {
-			Value struct {
-				Struct struct {
-					Member []struct {
-						Name  string        `xml:"name"`
-						Value EnhancedValue `xml:"value"`
-					} `xml:"member"`
-				} `xml:"struct"`
-			} `xml:"value"`
-		} `xml:"fault"`
-	}{}
-
-	if err := xml.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("XML解析失败: %v", err)
-	}
-
-	// 检查错误
-	if response.Fault != nil {
-		for _, member := range response.Fault.Value.Struct.Member {
-			if member.Name == "faultString" {
-				return nil, fmt.Errorf("XML-RPC错误: %s", member.Value.String)
-			}
-		}
-		return nil, fmt.Errorf("未知XML-RPC错误")
-	}
-
-	if len(response.Params) == 0 {
-		return nil, nil
+		return &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
-	// 解析并返回数据
-	return rc.parseEnhancedValue(response.Params[0].Value), nil
-}
-
-// parseEnhancedValue 将EnhancedValue转换为Go类型
-func (rc *RPCClient) parseEnhancedValue(ev EnhancedValue) interface{} {
-	if ev.String != "" {
-		return ev.String
-	}
-	if ev.Int != 0 || (ev.String == "" && !ev.Boolean && ev.Double == 0 && ev.Array.Data.Values == nil && ev.Struct.Members == nil) {
-		// 如果int不是0，或者这是唯一设置的字段，则返回int
-		return ev.Int
-	}
-	if ev.Boolean {
-		return ev.Boolean
-	}
-	if ev.Double != 0 {
-		return ev.Double
-	}
-	if ev.Array.Data.Values != nil {
-		// 解析数组
-		result := make([]interface{}, len(ev.Array.Data.Values))
-		for i, val := range ev.Array.Data.Values {
-			result[i] = rc.parseEnhancedValue(val)
-		}
-		return result
-	}
-	if ev.Struct.Members != nil {
-		// 解析结构体
-		result := make(map[string]interface{})
-		for _, member := range ev.Struct.Members {
-			result[member.Name] = rc.parseEnhancedValue(member.Value)
-		}
-		return result
-	}
-	return nil
+	// 解析响应，fault以 *Fault 类型返回
+	return DecodeMethodResponse(body, result)
 }
 
 // GetAllProcesses 获取所有进程信息
-func (rc *RPCClient) GetAllProcesses() ([]utils.ProcessInfo, error) {
+func (rc *RPCClient) GetAllProcesses(ctx context.Context) ([]utils.ProcessInfo, error) {
 	// 首先尝试使用RPC调用
-	result, err := rc.call("supervisor.getAllProcessInfo", nil)
+	infos, err := rc.GetAllProcessInfo(ctx)
 	if err != nil {
+		// 用户取消时不再回退
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		// 如果RPC调用失败，回退到使用命令行方式
 		fmt.Printf("⚠️  RPC调用失败: %v, 尝试使用命令行工具\n", err)
-		return rc.getAllProcessesViaCommand()
+		return rc.getAllProcessesViaCommand(ctx)
 	}
 
-	// 将结果转换为适当的类型
-	if processesData, ok := result.([]interface{}); ok {
-		processes := make([]utils.ProcessInfo, len(processesData))
-		for i, procData := range processesData {
-			if procMap, ok := procData.(map[string]interface{}); ok {
-				processes[i] = rc.parseProcessInfoFromMap(procMap, i+1)
-			}
-		}
-		return processes, nil
+	processes := make([]utils.ProcessInfo, len(infos))
+	for i, info := range infos {
+		processes[i] = rc.parseProcessInfo(info, i+1)
 	}
-
-	fmt.Println("⚠️  无法解析RPC响应数据，使用命令行工具作为回退")
-	return rc.getAllProcessesViaCommand()
+	return processes, nil
 }
 
-// parseProcessInfoFromMap 从map解析进程信息
-func (rc *RPCClient) parseProcessInfoFromMap(procMap map[string]interface{}, index int) utils.ProcessInfo {
-	name := ""
-	if n, ok := procMap["name"]; ok && n != nil {
-		if s, ok := n.(string); ok {
-			name = s
-		}
-	}
-
-	group := ""
-	if g, ok := procMap["group"]; ok && g != nil {
-		if s, ok := g.(string); ok {
-			group = s
-		}
-	}
-
-	state := 0
-	if s, ok := procMap["state"]; ok && s != nil {
-		if f, ok := s.(float64); ok {
-			state = int(f)
-		} else if i, ok := s.(int); ok {
-			state = i
-		} else if i, ok := s.(float32); ok {
-			state = int(i)
-		}
-	}
-
-	stateName := ""
-	if sn, ok := procMap["statename"]; ok && sn != nil {
-		if s, ok := sn.(string); ok {
-			stateName = s
-		}
+// parseProcessInfo 将RPC进程信息转换为显示用的进程信息
+func (rc *RPCClient) parseProcessInfo(info ProcessInfoRPC, index int) utils.ProcessInfo {
+	return utils.ProcessInfo{
+		Index:         index,
+		Name:          info.FullName(), // 使用完整进程名称 (group:name)
+		Group:         info.Group,
+		State:         info.State,
+		StateName:     info.StateName,
+		PID:           info.Pid,
+		Uptime:        processUptime(info),
+		Description:   utils.GetStateIcon(info.State),
+		ExitStatus:    info.ExitStatus,
+		SpawnErr:      info.SpawnErr,
+		StartTime:     unixTime(info.Start),
+		StopTime:      unixTime(info.Stop),
+		StdoutLogfile: info.StdoutLogfile,
+		StderrLogfile: info.StderrLogfile,
 	}
+}
 
-	pid := 0
-	if p, ok := procMap["pid"]; ok && p != nil {
-		if f, ok := p.(float64); ok {
-			pid = int(f)
-		} else if i, ok := p.(int); ok {
-			pid = i
-		} else if i, ok := p.(float32); ok {
-			pid = int(i)
-		}
+// processUptime 根据start和now字段计算运行时间
+func processUptime(info ProcessInfoRPC) string {
+	if info.Pid <= 0 {
+		return "已停止"
 	}
-
-	description := ""
-	if d, ok := procMap["description"]; ok && d != nil {
-		if s, ok := d.(string); ok {
-			description = s
+	if info.Start > 0 && info.Now >= info.Start {
+		seconds := int(info.Now - info.Start)
+		if seconds == 0 {
+			// FormatUptime 把0秒显示为已停止，刚启动的进程单独处理
+			return "00分钟00秒"
 		}
+		return utils.FormatUptime(seconds)
 	}
 
-	// 生成完整进程名称 (group:name)
-	fullName := name
-	if group != "" && name != "" {
-		fullName = group + ":" + name
-	}
-
-	// 生成状态描述 - 处理运行时间
-	var uptime string
-	if pid > 0 {
-		// 如果有PID，需要从描述中提取运行时间
-		// description 格式通常是 "pid 12345, uptime 4:46:03"
-		if strings.Contains(description, "uptime") {
-			// 提取 uptime 后面的时间部分
-			parts := strings.Split(description, "uptime")
-			if len(parts) > 1 {
-				timeStr := strings.TrimSpace(parts[1])
-				// 移除可能的逗号
-				if strings.HasSuffix(timeStr, ",") {
-					timeStr = strings.TrimSuffix(timeStr, ",")
-				}
-				// 使用 utils 包中的函数来格式化时间
-				uptime = utils.ProcessUptimeString(timeStr)
-			} else {
-				uptime = description
-			}
-		} else {
-			uptime = description
-		}
-	} else {
-		uptime = "已停止"
+	// 缺少时间字段时从描述中提取，格式通常是 "pid 12345, uptime 4:46:03"
+	if _, uptime, found := strings.Cut(info.Description, "uptime"); found {
+		return utils.ProcessUptimeString(strings.TrimSpace(uptime))
 	}
+	return info.Description
+}
 
-	return utils.ProcessInfo{
-		Index:       index,
-		Name:        fullName,  // 使用完整进程名称
-		Group:       group,
-		State:       state,
-		StateName:   stateName,
-		PID:         pid,
-		Uptime:      uptime,
-		Description: utils.GetStateIcon(state),
-		ExitStatus:  0,
+// unixTime 将RPC返回的Unix时间戳转换为time.Time，0表示未发生
+func unixTime(seconds float64) time.Time {
+	if seconds <= 0 {
+		return time.Time{}
 	}
+	return time.Unix(int64(seconds), 0)
 }
 
 // getAllProcessesViaCommand 通过命令行方式获取进程信息（回退方案）
-func (rc *RPCClient) getAllProcessesViaCommand() ([]utils.ProcessInfo, error) {
+func (rc *RPCClient) getAllProcessesViaCommand(ctx context.Context) ([]utils.ProcessInfo, error) {
 	// 尝试使用 supervisorctl 命令获取真实数据
 	fmt.Println("正在获取Supervisor进程状态...")
-	cmd := exec.Command("supervisorctl", "status")
+	cmd := exec.CommandContext(ctx, "supervisorctl", "status")
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// 即使有错误，output中通常也包含有用的信息
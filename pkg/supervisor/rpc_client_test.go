@@ -0,0 +1,61 @@
+package supervisor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRPCClient_GetAllProcesses 测试从数值字段计算运行时间并填充详细信息
+func TestRPCClient_GetAllProcesses(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.getAllProcessInfo", []interface{}{
+		map[string]interface{}{
+			"name": "web_00", "group": "web", "state": 20, "statename": "RUNNING", "pid": 100,
+			"start": 1700000000, "now": 1700000000 + 30*86400 + 16*3600 + 17*60 + 38, "stop": 0,
+			"description":    "pid 100, uptime 30 days, 16:17:38",
+			"stdout_logfile": "/var/log/web.out.log", "stderr_logfile": "/var/log/web.err.log",
+		},
+		map[string]interface{}{
+			"name": "worker", "group": "worker", "state": ProcessStateFatal, "statename": "FATAL", "pid": 0,
+			"start": 1700000000, "stop": 1700000005, "now": 1700000100, "exitstatus": 127,
+			"spawnerr": "can't find command 'missing'", "description": "can't find command 'missing'",
+		},
+	})
+
+	processes, err := client.GetAllProcesses(context.Background())
+	require.NoError(t, err)
+	require.Len(t, processes, 2)
+
+	web := processes[0]
+	assert.Equal(t, 1, web.Index)
+	assert.Equal(t, "web:web_00", web.Name)
+	assert.Equal(t, "30天16小时17分钟38秒", web.Uptime)
+	assert.Equal(t, time.Unix(1700000000, 0), web.StartTime)
+	assert.True(t, web.StopTime.IsZero())
+	assert.Equal(t, "/var/log/web.out.log", web.StdoutLogfile)
+	assert.Equal(t, "/var/log/web.err.log", web.StderrLogfile)
+
+	worker := processes[1]
+	assert.Equal(t, ProcessStateFatal, worker.State)
+	assert.Equal(t, "❌ 致命错误", worker.Description)
+	assert.Equal(t, "已停止", worker.Uptime)
+	assert.Equal(t, 127, worker.ExitStatus)
+	assert.Equal(t, "can't find command 'missing'", worker.SpawnErr)
+	assert.Equal(t, time.Unix(1700000005, 0), worker.StopTime)
+}
+
+// TestProcessUptime 测试运行时间的计算和回退
+func TestProcessUptime(t *testing.T) {
+	assert.Equal(t, "00分钟00秒", processUptime(ProcessInfoRPC{Pid: 1, Start: 10, Now: 10}))
+	assert.Equal(t, "01分钟05秒", processUptime(ProcessInfoRPC{Pid: 1, Start: 10, Now: 75}))
+	// 缺少时间字段时从描述中提取
+	assert.Equal(t, "2天03分钟04秒", processUptime(ProcessInfoRPC{Pid: 1, Description: "pid 1, uptime 2 days, 0:03:04"}))
+	// 两种来源的格式相同
+	assert.Equal(t, "30天16小时17分钟38秒", processUptime(ProcessInfoRPC{Pid: 1, Start: 0.5, Now: 0.5 + 30*86400 + 16*3600 + 17*60 + 38}))
+	assert.Equal(t, "30天16小时17分钟38秒", processUptime(ProcessInfoRPC{Pid: 1, Description: "pid 1, uptime 30 days, 16:17:38"}))
+	assert.Equal(t, "已停止", processUptime(ProcessInfoRPC{Start: 10, Now: 75}))
+}
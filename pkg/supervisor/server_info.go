@@ -0,0 +1,139 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// 与supervisord的连接方式
+const (
+	TransportRPC     = "XML-RPC"
+	TransportCommand = "supervisorctl"
+)
+
+// ServerInfo supervisord的身份和运行状态
+type ServerInfo struct {
+	Transport      string // TransportRPC 或 TransportCommand
+	State          SupervisorState
+	PID            int
+	APIVersion     string // 通过supervisorctl获取时为空
+	Version        string
+	Identification string // 通过supervisorctl获取时为空
+}
+
+// Host 返回客户端连接的地址
+func (rc *RPCClient) Host() string {
+	return rc.host
+}
+
+// GetServerInfo 通过一次multicall获取supervisord的状态、PID、版本和标识
+func (rc *RPCClient) GetServerInfo(ctx context.Context) (ServerInfo, error) {
+	results, err := rc.Multicall(ctx, []MulticallCall{
+		{Method: "supervisor.getState"},
+		{Method: "supervisor.getPID"},
+		{Method: "supervisor.getAPIVersion"},
+		{Method: "supervisor.getSupervisorVersion"},
+		{Method: "supervisor.getIdentification"},
+	})
+	if err != nil {
+		return ServerInfo{}, err
+	}
+
+	info := ServerInfo{Transport: TransportRPC}
+	targets := []interface{}{&info.State, &info.PID, &info.APIVersion, &info.Version, &info.Identification}
+	for i, target := range targets {
+		if err := results[i].Decode(target); err != nil {
+			return ServerInfo{}, err
+		}
+	}
+	return info, nil
+}
+
+// ServerInfo 获取supervisord信息，RPC不可用且连接本机时回退到supervisorctl
+func (rc *RPCClient) ServerInfo(ctx context.Context) (ServerInfo, error) {
+	info, err := rc.GetServerInfo(ctx)
+	if err == nil || IsFault(err) || ctx.Err() != nil || !rc.IsLocal() {
+		return info, err
+	}
+
+	cmdInfo, cmdErr := getServerInfoViaCommand(ctx)
+	if cmdErr != nil {
+		return ServerInfo{}, fmt.Errorf("%w (supervisorctl回退也失败: %v)", err, cmdErr)
+	}
+	return cmdInfo, nil
+}
+
+// getServerInfoViaCommand 通过supervisorctl获取supervisord的PID和版本（回退方案）
+func getServerInfoViaCommand(ctx context.Context) (ServerInfo, error) {
+	output, err := exec.CommandContext(ctx, "supervisorctl", "pid").CombinedOutput()
+	if err != nil {
+		return ServerInfo{}, fmt.Errorf("%v, 输出: %s", err, strings.TrimSpace(string(output)))
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return ServerInfo{}, fmt.Errorf("无法解析supervisorctl pid的输出: %s", strings.TrimSpace(string(output)))
+	}
+
+	info := ServerInfo{
+		Transport: TransportCommand,
+		// supervisorctl能返回PID说明supervisord正在运行
+		State: SupervisorState{Code: SupervisorStateRunning, Name: "RUNNING"},
+		PID:   pid,
+	}
+	if output, err := exec.CommandContext(ctx, "supervisorctl", "version").Output(); err == nil {
+		info.Version = strings.TrimSpace(string(output))
+	}
+	return info, nil
+}
+
+// ConfigFilePath 获取本机supervisord使用的配置文件路径
+//
+// 优先从进程命令行的 -c/--configuration 参数获取，否则返回第一个存在的默认配置文件。
+func (cd *ConfigDetector) ConfigFilePath(pid int) string {
+	if pid > 0 {
+		procDir := filepath.Join("/proc", strconv.Itoa(pid))
+		if cmdline, err := os.ReadFile(filepath.Join(procDir, "cmdline")); err == nil {
+			args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+			if path := parseConfigFlag(args); path != "" {
+				if !filepath.IsAbs(path) {
+					if cwd, err := os.Readlink(filepath.Join(procDir, "cwd")); err == nil {
+						path = filepath.Join(cwd, path)
+					}
+				}
+				return path
+			}
+		}
+	}
+
+	for _, configPath := range supervisorConfigPaths {
+		if strings.Contains(configPath, "*") {
+			continue
+		}
+		if _, err := os.Stat(configPath); err == nil {
+			return configPath
+		}
+	}
+	return ""
+}
+
+// parseConfigFlag 从supervisord的命令行参数中解析配置文件路径
+func parseConfigFlag(args []string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "-c" || arg == "--configuration":
+			if i+1 < len(args) {
+				return args[i+1]
+			}
+		case strings.HasPrefix(arg, "--configuration="):
+			return strings.TrimPrefix(arg, "--configuration=")
+		case strings.HasPrefix(arg, "-c") && !strings.HasPrefix(arg, "--"):
+			return strings.TrimPrefix(arg, "-c")
+		}
+	}
+	return ""
+}
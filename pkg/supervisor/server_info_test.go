@@ -0,0 +1,57 @@
+package supervisor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRPCClient_GetServerInfo 测试通过multicall获取supervisord信息
+func TestRPCClient_GetServerInfo(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.result("supervisor.getState", map[string]interface{}{"statecode": 1, "statename": "RUNNING"})
+	fs.result("supervisor.getPID", 4321)
+	fs.result("supervisor.getAPIVersion", "3.0")
+	fs.result("supervisor.getSupervisorVersion", "4.2.5")
+	fs.result("supervisor.getIdentification", "supervisor")
+
+	info, err := client.ServerInfo(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, ServerInfo{
+		Transport:      TransportRPC,
+		State:          SupervisorState{Code: SupervisorStateRunning, Name: "RUNNING"},
+		PID:            4321,
+		APIVersion:     "3.0",
+		Version:        "4.2.5",
+		Identification: "supervisor",
+	}, info)
+}
+
+// TestRPCClient_ServerInfo_FaultDoesNotFallback 测试Supervisor返回fault时不回退
+func TestRPCClient_ServerInfo_FaultDoesNotFallback(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.handle("supervisor.getState", func([]interface{}) (interface{}, error) {
+		return nil, &Fault{Code: FaultShutdownState, String: "SHUTDOWN_STATE"}
+	})
+	_, err := client.ServerInfo(context.Background())
+	assert.ErrorIs(t, err, ErrShutdownState)
+}
+
+// TestParseConfigFlag 测试从supervisord命令行解析配置文件
+func TestParseConfigFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"/usr/bin/python3", "/usr/bin/supervisord", "-n", "-c", "/etc/supervisor/supervisord.conf"}, "/etc/supervisor/supervisord.conf"},
+		{[]string{"supervisord", "--configuration=/etc/supervisord.conf"}, "/etc/supervisord.conf"},
+		{[]string{"supervisord", "--configuration", "sv.conf"}, "sv.conf"},
+		{[]string{"supervisord", "-c/tmp/a.conf"}, "/tmp/a.conf"},
+		{[]string{"supervisord", "-n"}, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, parseConfigFlag(tt.args), tt.args)
+	}
+}
@@ -0,0 +1,182 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// SignalAllTarget 表示向所有进程发送信号的目标
+const SignalAllTarget = "all"
+
+// signalNames supervisord 所在系统上常见的信号名称（不含SIG前缀）
+// 信号名由supervisord解析，因此不使用本机的信号编号
+var signalNames = map[string]bool{
+	"HUP": true, "INT": true, "QUIT": true, "ILL": true, "TRAP": true, "ABRT": true,
+	"BUS": true, "FPE": true, "KILL": true, "USR1": true, "SEGV": true, "USR2": true,
+	"PIPE": true, "ALRM": true, "TERM": true, "STKFLT": true, "CHLD": true, "CONT": true,
+	"STOP": true, "TSTP": true, "TTIN": true, "TTOU": true, "URG": true, "XCPU": true,
+	"XFSZ": true, "VTALRM": true, "PROF": true, "WINCH": true, "IO": true, "PWR": true,
+	"SYS": true,
+}
+
+// NormalizeSignal 规范化信号参数，支持 HUP、SIGHUP、hup 等名称和 1-64 的信号编号
+func NormalizeSignal(signal string) (string, error) {
+	signal = strings.TrimSpace(signal)
+	if n, err := strconv.Atoi(signal); err == nil {
+		if n < 1 || n > 64 {
+			return "", fmt.Errorf("%w: %s", ErrBadSignal, signal)
+		}
+		return strconv.Itoa(n), nil
+	}
+
+	name := strings.TrimPrefix(strings.ToUpper(signal), "SIG")
+	if !signalNames[name] {
+		return "", fmt.Errorf("%w: %s", ErrBadSignal, signal)
+	}
+	return name, nil
+}
+
+// IsGroupTarget 检查目标是否为 "group:*" 形式的进程组
+func IsGroupTarget(target string) bool {
+	return strings.HasSuffix(target, ":*") && len(target) > 2
+}
+
+// SignalResult 单个进程的信号发送结果
+type SignalResult struct {
+	Name string // 进程名；进程组或全部进程的请求整体失败时为原始目标
+	Err  error
+}
+
+// SignalProcesses 向目标发送信号，返回每个进程的结果
+//
+// 目标可以是进程名、"group:*" 形式的进程组或 SignalAllTarget，
+// 分别通过 signalProcess、signalProcessGroup、signalAllProcesses 在一次multicall中发送。
+func (pc *ProcessController) SignalProcesses(ctx context.Context, signal string, targets []string) []SignalResult {
+	signal, err := NormalizeSignal(signal)
+	if err != nil {
+		return targetErrors(targets, err)
+	}
+
+	var calls []MulticallCall
+	var valid []string
+	var results []SignalResult
+	for _, target := range targets {
+		if err := validateSignalTarget(target); err != nil {
+			results = append(results, SignalResult{Name: target, Err: err})
+			continue
+		}
+		valid = append(valid, target)
+		calls = append(calls, signalCall(target, signal))
+	}
+	if len(valid) == 0 {
+		return results
+	}
+
+	if ctx.Err() != nil {
+		return append(results, targetErrors(valid, fmt.Errorf("发送信号失败: %w", ctx.Err()))...)
+	}
+
+	if pc.client == nil {
+		if pc.fallback {
+			return append(results, pc.signalViaCommand(ctx, signal, valid)...)
+		}
+		return append(results, targetErrors(valid, fmt.Errorf("发送信号失败: 未配置RPC客户端"))...)
+	}
+
+	replies, err := pc.client.Multicall(ctx, calls)
+	if err != nil {
+		if pc.fallback && !IsFault(err) && ctx.Err() == nil {
+			return append(results, pc.signalViaCommand(ctx, signal, valid)...)
+		}
+		return append(results, targetErrors(valid, fmt.Errorf("发送信号失败: %w", err))...)
+	}
+
+	for k, target := range valid {
+		reply := replies[k]
+		if reply.Err != nil {
+			results = append(results, SignalResult{Name: target, Err: fmt.Errorf("发送信号失败: %w", reply.Err)})
+			continue
+		}
+		if !IsGroupTarget(target) && target != SignalAllTarget {
+			results = append(results, SignalResult{Name: target})
+			continue
+		}
+
+		// 进程组和全部进程的调用返回每个进程的结果
+		var statuses []ProcessStatus
+		if err := reply.Decode(&statuses); err != nil {
+			results = append(results, SignalResult{Name: target, Err: fmt.Errorf("发送信号失败: %w", err)})
+			continue
+		}
+		for _, status := range statuses {
+			result := SignalResult{Name: status.FullName()}
+			if err := status.Err(); err != nil {
+				result.Err = fmt.Errorf("发送信号失败: %w", err)
+			}
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// signalCall 根据目标类型构造信号调用
+func signalCall(target, signal string) MulticallCall {
+	switch {
+	case target == SignalAllTarget:
+		return MulticallCall{Method: "supervisor.signalAllProcesses", Params: []interface{}{signal}}
+	case IsGroupTarget(target):
+		group := strings.TrimSuffix(target, ":*")
+		return MulticallCall{Method: "supervisor.signalProcessGroup", Params: []interface{}{group, signal}}
+	default:
+		return MulticallCall{Method: "supervisor.signalProcess", Params: []interface{}{target, signal}}
+	}
+}
+
+// validateSignalTarget 验证信号目标名称
+func validateSignalTarget(target string) error {
+	if target == SignalAllTarget {
+		return nil
+	}
+	return validateProcessName(strings.TrimSuffix(target, ":*"))
+}
+
+// signalViaCommand 通过supervisorctl发送信号（回退方案）
+func (pc *ProcessController) signalViaCommand(ctx context.Context, signal string, targets []string) []SignalResult {
+	results := make([]SignalResult, len(targets))
+	for i, target := range targets {
+		results[i].Name = target
+		cmd := exec.CommandContext(ctx, "supervisorctl", "signal", signal, target)
+		output, err := cmd.CombinedOutput()
+		switch {
+		case err != nil:
+			results[i].Err = fmt.Errorf("发送信号失败: %v, 输出: %s", err, string(output))
+		case strings.Contains(string(output), "ERROR"):
+			results[i].Err = fmt.Errorf("发送信号失败: %s", string(output))
+		}
+	}
+	return results
+}
+
+// targetErrors 为每个目标返回相同的错误
+func targetErrors(targets []string, err error) []SignalResult {
+	results := make([]SignalResult, len(targets))
+	for i, target := range targets {
+		results[i] = SignalResult{Name: target, Err: err}
+	}
+	return results
+}
+
+// portableSignalNames 各平台编号一致的信号
+var portableSignalNames = map[int]string{1: "HUP", 2: "INT", 3: "QUIT", 9: "KILL", 15: "TERM"}
+
+// SignalName 返回信号编号的显示名称，例如 "15 (TERM)"
+// 只有各平台编号一致的信号才显示名称，其余信号的编号取决于supervisord所在的系统
+func SignalName(signal int) string {
+	if name, ok := portableSignalNames[signal]; ok {
+		return fmt.Sprintf("%d (%s)", signal, name)
+	}
+	return strconv.Itoa(signal)
+}
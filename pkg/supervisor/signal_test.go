@@ -0,0 +1,93 @@
+package supervisor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNormalizeSignal 测试信号名称和编号的规范化
+func TestNormalizeSignal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"HUP", "HUP"},
+		{"sighup", "HUP"},
+		{"SIGUSR1", "USR1"},
+		{"usr2", "USR2"},
+		{"15", "15"},
+	}
+	for _, tt := range tests {
+		signal, err := NormalizeSignal(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.expected, signal)
+	}
+
+	for _, invalid := range []string{"", "FOO", "0", "65", "SIG"} {
+		_, err := NormalizeSignal(invalid)
+		assert.ErrorIs(t, err, ErrBadSignal, invalid)
+	}
+}
+
+// TestSignalProcesses 测试进程、进程组和全部进程的信号发送
+func TestSignalProcesses(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	var signals []interface{}
+	fs.handle("supervisor.signalProcess", func(params []interface{}) (interface{}, error) {
+		signals = append(signals, params[1])
+		if params[0] == "web:stopped" {
+			return nil, &Fault{Code: FaultNotRunning, String: "NOT_RUNNING"}
+		}
+		return true, nil
+	})
+	fs.result("supervisor.signalProcessGroup", []interface{}{
+		map[string]interface{}{"name": "w1", "group": "worker", "status": FaultSuccess, "description": "OK"},
+		map[string]interface{}{"name": "w2", "group": "worker", "status": FaultBadSignal, "description": "BAD_SIGNAL"},
+	})
+	fs.result("supervisor.signalAllProcesses", []interface{}{
+		map[string]interface{}{"name": "api", "group": "api", "status": FaultSuccess, "description": "OK"},
+	})
+
+	ctrl := NewProcessController(client)
+	results := ctrl.SignalProcesses(context.Background(), "sighup",
+		[]string{"web:web_00", "web:stopped", "worker:*", "all", "bad;name"})
+
+	require.Len(t, results, 6)
+	assert.Equal(t, SignalResult{Name: "bad;name", Err: results[0].Err}, results[0])
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, SignalResult{Name: "web:web_00"}, results[1])
+	assert.Equal(t, "web:stopped", results[2].Name)
+	assert.ErrorIs(t, results[2].Err, ErrNotRunning)
+	assert.Equal(t, SignalResult{Name: "worker:w1"}, results[3])
+	assert.Equal(t, "worker:w2", results[4].Name)
+	assert.ErrorIs(t, results[4].Err, ErrBadSignal)
+	assert.Equal(t, SignalResult{Name: "api:api"}, results[5])
+
+	assert.Equal(t, []interface{}{"HUP", "HUP"}, signals)
+	assert.Equal(t, []string{"supervisor.signalProcess", "supervisor.signalProcess",
+		"supervisor.signalProcessGroup", "supervisor.signalAllProcesses"}, fs.methods())
+}
+
+// TestSignalProcesses_BadSignal 测试无效信号不发送请求
+func TestSignalProcesses_BadSignal(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	results := NewProcessController(client).SignalProcesses(context.Background(), "NOPE", []string{"web"})
+	require.Len(t, results, 1)
+	assert.ErrorIs(t, results[0].Err, ErrBadSignal)
+	assert.Empty(t, fs.methods())
+}
+
+// TestSignalProcesses_GroupFault 测试进程组不存在时报告原始目标
+func TestSignalProcesses_GroupFault(t *testing.T) {
+	fs, client := newFakeSupervisor(t)
+	fs.handle("supervisor.signalProcessGroup", func([]interface{}) (interface{}, error) {
+		return nil, &Fault{Code: FaultBadName, String: "BAD_NAME: nope"}
+	})
+	results := NewProcessController(client).SignalProcesses(context.Background(), "TERM", []string{"nope:*"})
+	require.Len(t, results, 1)
+	assert.Equal(t, "nope:*", results[0].Name)
+	assert.ErrorIs(t, results[0].Err, ErrBadName)
+}
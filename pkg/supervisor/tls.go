@@ -0,0 +1,71 @@
+package supervisor
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// TLSOptions 连接HTTPS Supervisor端点（如反向代理之后的supervisord）时的TLS选项
+type TLSOptions struct {
+	CAFile             string // 自定义CA证书文件(PEM)，为空时使用系统证书
+	CertFile           string // 客户端证书文件(PEM)，用于mTLS
+	KeyFile            string // 客户端私钥文件(PEM)，用于mTLS
+	ServerName         string // SNI及证书校验使用的服务器名，为空时使用URL中的主机名
+	InsecureSkipVerify bool   // 跳过服务端证书校验，仅用于测试环境
+}
+
+// IsZero 判断是否未设置任何TLS选项
+func (o TLSOptions) IsZero() bool {
+	return o == TLSOptions{}
+}
+
+// TLSConfig 根据选项构造tls.Config
+func (o TLSOptions) TLSConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CAFile != "" {
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("读取CA证书失败: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA证书文件中没有有效的PEM证书: %s", o.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		return nil, fmt.Errorf("客户端证书和私钥必须同时指定")
+	}
+	if o.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.CertFile, o.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载客户端证书失败: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// SetTLS 为客户端配置TLS选项，重定向限制等其他客户端设置保持不变
+func (rc *RPCClient) SetTLS(opts TLSOptions) error {
+	config, err := opts.TLSConfig()
+	if err != nil {
+		return err
+	}
+	transport, ok := rc.client.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("当前传输层不支持TLS配置")
+	}
+	transport.TLSClientConfig = config
+	return nil
+}
@@ -0,0 +1,124 @@
+package supervisor
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTLSFakeSupervisor 启动HTTPS模拟服务，返回服务和其证书的PEM文件路径
+func newTLSFakeSupervisor(t *testing.T, configure func(*tls.Config)) (*httptest.Server, string) {
+	fs := &fakeSupervisor{t: t, handlers: make(map[string]fakeHandler)}
+	fs.result("supervisor.getPID", 1)
+
+	server := httptest.NewUnstartedServer(fs)
+	server.TLS = &tls.Config{}
+	if configure != nil {
+		configure(server.TLS)
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writePEM(t, caFile, "CERTIFICATE", server.Certificate().Raw)
+	return server, caFile
+}
+
+// writePEM 写入PEM文件
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+}
+
+// TestSetTLS_CAFile 测试使用自定义CA校验服务端证书
+func TestSetTLS_CAFile(t *testing.T) {
+	server, caFile := newTLSFakeSupervisor(t, nil)
+
+	// 未配置CA时证书校验失败
+	client := NewRPCClient(server.URL+"/RPC2", "", "")
+	_, err := client.GetPID(context.Background())
+	require.Error(t, err)
+
+	client = NewRPCClient(server.URL+"/RPC2", "", "")
+	require.NoError(t, client.SetTLS(TLSOptions{CAFile: caFile, ServerName: "example.com"}))
+	pid, err := client.GetPID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, pid)
+}
+
+// TestSetTLS_InsecureSkipVerify 测试跳过证书校验
+func TestSetTLS_InsecureSkipVerify(t *testing.T) {
+	server, _ := newTLSFakeSupervisor(t, nil)
+
+	client := NewRPCClient(server.URL+"/RPC2", "", "")
+	require.NoError(t, client.SetTLS(TLSOptions{InsecureSkipVerify: true}))
+	_, err := client.GetPID(context.Background())
+	assert.NoError(t, err)
+}
+
+// TestSetTLS_ClientCertificate 测试mTLS客户端证书
+func TestSetTLS_ClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sv-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+
+	clientCert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	server, caFile := newTLSFakeSupervisor(t, func(config *tls.Config) {
+		pool := x509.NewCertPool()
+		pool.AddCert(clientCert)
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.ClientCAs = pool
+	})
+
+	// 没有客户端证书时握手失败
+	client := NewRPCClient(server.URL+"/RPC2", "", "")
+	require.NoError(t, client.SetTLS(TLSOptions{CAFile: caFile}))
+	_, err = client.GetPID(context.Background())
+	require.Error(t, err)
+
+	client = NewRPCClient(server.URL+"/RPC2", "", "")
+	require.NoError(t, client.SetTLS(TLSOptions{CAFile: caFile, CertFile: certFile, KeyFile: keyFile}))
+	_, err = client.GetPID(context.Background())
+	assert.NoError(t, err)
+}
+
+// TestTLSOptions_Invalid 测试无效的TLS选项
+func TestTLSOptions_Invalid(t *testing.T) {
+	_, err := TLSOptions{CertFile: "client.pem"}.TLSConfig()
+	assert.Error(t, err)
+
+	_, err = TLSOptions{CAFile: filepath.Join(t.TempDir(), "missing.pem")}.TLSConfig()
+	assert.Error(t, err)
+
+	assert.True(t, TLSOptions{}.IsZero())
+}
@@ -0,0 +1,660 @@
+package supervisor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xmlrpcDateTimeFormat XML-RPC dateTime.iso8601 的标准格式
+const xmlrpcDateTimeFormat = "20060102T15:04:05"
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// EncodeMethodCall 将方法名和参数编码为XML-RPC请求体
+//
+// 支持的参数类型: nil、bool、整数、浮点数、string、time.Time、[]byte(base64)、
+// 切片/数组、map[string]T 以及按 xml 标签映射成员名的结构体
+func EncodeMethodCall(method string, params ...interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodCall><methodName>")
+	if err := xml.EscapeText(&buf, []byte(method)); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</methodName><params>")
+	for i, param := range params {
+		buf.WriteString("<param>")
+		if err := encodeValue(&buf, reflect.ValueOf(param)); err != nil {
+			return nil, fmt.Errorf("编码第%d个参数失败: %v", i+1, err)
+		}
+		buf.WriteString("</param>")
+	}
+	buf.WriteString("</params></methodCall>")
+	return buf.Bytes(), nil
+}
+
+// encodeValue 将Go值编码为XML-RPC <value>元素
+func encodeValue(buf *bytes.Buffer, v reflect.Value) error {
+	buf.WriteString("<value>")
+	if err := encodeInner(buf, v); err != nil {
+		return err
+	}
+	buf.WriteString("</value>")
+	return nil
+}
+
+// encodeInner 编码<value>内部的类型元素
+func encodeInner(buf *bytes.Buffer, v reflect.Value) error {
+	if !v.IsValid() {
+		buf.WriteString("<nil/>")
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			buf.WriteString("<nil/>")
+			return nil
+		}
+		return encodeInner(buf, v.Elem())
+	}
+
+	if v.Type() == timeType {
+		fmt.Fprintf(buf, "<dateTime.iso8601>%s</dateTime.iso8601>", v.Interface().(time.Time).Format(xmlrpcDateTimeFormat))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			buf.WriteString("<boolean>1</boolean>")
+		} else {
+			buf.WriteString("<boolean>0</boolean>")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(buf, "<int>%d</int>", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fmt.Fprintf(buf, "<int>%d</int>", v.Uint())
+	case reflect.Float32, reflect.Float64:
+		buf.WriteString("<double>")
+		buf.WriteString(strconv.FormatFloat(v.Float(), 'f', -1, 64))
+		buf.WriteString("</double>")
+	case reflect.String:
+		buf.WriteString("<string>")
+		if err := xml.EscapeText(buf, []byte(v.String())); err != nil {
+			return err
+		}
+		buf.WriteString("</string>")
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			data := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(data), v)
+			buf.WriteString("<base64>")
+			buf.WriteString(base64.StdEncoding.EncodeToString(data))
+			buf.WriteString("</base64>")
+			return nil
+		}
+		buf.WriteString("<array><data>")
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeValue(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</data></array>")
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("不支持的map键类型: %s", v.Type().Key())
+		}
+		// 按键排序，保证输出稳定
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		buf.WriteString("<struct>")
+		for _, key := range keys {
+			if err := encodeMember(buf, key.String(), v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</struct>")
+	case reflect.Struct:
+		buf.WriteString("<struct>")
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, omitEmpty, ok := xmlrpcFieldName(field)
+			if !ok {
+				continue
+			}
+			fv := v.Field(i)
+			if omitEmpty && fv.IsZero() {
+				continue
+			}
+			if err := encodeMember(buf, name, fv); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</struct>")
+	default:
+		return fmt.Errorf("不支持的参数类型: %s", v.Type())
+	}
+	return nil
+}
+
+// encodeMember 编码结构体成员
+func encodeMember(buf *bytes.Buffer, name string, v reflect.Value) error {
+	buf.WriteString("<member><name>")
+	if err := xml.EscapeText(buf, []byte(name)); err != nil {
+		return err
+	}
+	buf.WriteString("</name>")
+	if err := encodeValue(buf, v); err != nil {
+		return fmt.Errorf("成员 %s: %v", name, err)
+	}
+	buf.WriteString("</member>")
+	return nil
+}
+
+// xmlrpcFieldName 根据xml标签获取结构体字段对应的成员名
+func xmlrpcFieldName(field reflect.StructField) (name string, omitEmpty bool, ok bool) {
+	if field.PkgPath != "" { // 未导出字段
+		return "", false, false
+	}
+	tag := field.Tag.Get("xml")
+	if tag == "-" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, true
+}
+
+// DecodeMethodResponse 解析XML-RPC响应并将返回值解码到result中
+//
+// result 必须是非nil指针，为nil时只检查响应是否有效。
+// 如果响应是fault，返回 *Fault 类型的错误。
+func DecodeMethodResponse(data []byte, result interface{}) error {
+	value, err := parseMethodResponse(data)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	return DecodeValue(value, result)
+}
+
+// parseMethodResponse 将XML-RPC响应解析为通用Go值
+//
+// 通用值的类型: nil、int、float64、bool、string、time.Time、[]byte、
+// []interface{} 和 map[string]interface{}
+func parseMethodResponse(data []byte) (interface{}, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	root, ok, err := nextStart(dec)
+	if err != nil {
+		return nil, fmt.Errorf("XML解析失败: %v", err)
+	}
+	if !ok || root.Name.Local != "methodResponse" {
+		return nil, fmt.Errorf("XML解析失败: 缺少methodResponse元素")
+	}
+
+	elem, ok, err := nextStart(dec)
+	if err != nil {
+		return nil, fmt.Errorf("XML解析失败: %v", err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("XML解析失败: 空的methodResponse")
+	}
+
+	switch elem.Name.Local {
+	case "params":
+		param, ok, err := nextStart(dec)
+		if err != nil {
+			return nil, fmt.Errorf("XML解析失败: %v", err)
+		}
+		if !ok {
+			return nil, nil
+		}
+		if param.Name.Local != "param" {
+			return nil, fmt.Errorf("XML解析失败: 意外的元素 <%s>", param.Name.Local)
+		}
+		value, err := expectValue(dec)
+		if err != nil {
+			return nil, fmt.Errorf("XML解析失败: %v", err)
+		}
+		return value, nil
+	case "fault":
+		value, err := expectValue(dec)
+		if err != nil {
+			return nil, fmt.Errorf("XML解析失败: %v", err)
+		}
+		fault := &Fault{}
+		if err := DecodeValue(value, fault); err != nil {
+			return nil, fmt.Errorf("解析fault失败: %v", err)
+		}
+		return nil, fault
+	default:
+		return nil, fmt.Errorf("XML解析失败: 意外的元素 <%s>", elem.Name.Local)
+	}
+}
+
+// nextStart 读取下一个开始标签，遇到当前元素的结束标签时返回 ok=false
+func nextStart(dec *xml.Decoder) (xml.StartElement, bool, error) {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return xml.StartElement{}, false, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			return t, true, nil
+		case xml.EndElement:
+			return xml.StartElement{}, false, nil
+		}
+	}
+}
+
+// expectValue 读取一个<value>元素
+func expectValue(dec *xml.Decoder) (interface{}, error) {
+	start, ok, err := nextStart(dec)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || start.Name.Local != "value" {
+		return nil, fmt.Errorf("缺少value元素")
+	}
+	return parseValue(dec)
+}
+
+// parseValue 解析<value>元素的内容（已读取开始标签）
+func parseValue(dec *xml.Decoder) (interface{}, error) {
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			value, err := parseTyped(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			// 类型元素之后只允许出现</value>
+			if _, ok, err := nextStart(dec); err != nil {
+				return nil, err
+			} else if ok {
+				return nil, fmt.Errorf("value元素中包含多个类型")
+			}
+			return value, nil
+		case xml.EndElement:
+			// 没有类型元素的value按string处理
+			return text.String(), nil
+		}
+	}
+}
+
+// parseTyped 解析<value>中的类型元素
+func parseTyped(dec *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "i4", "int", "i8":
+		text, err := readText(dec, start)
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的整数: %q", text)
+		}
+		return int(n), nil
+	case "boolean":
+		text, err := readText(dec, start)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.TrimSpace(text) {
+		case "1", "true":
+			return true, nil
+		case "0", "false":
+			return false, nil
+		}
+		return nil, fmt.Errorf("无效的布尔值: %q", text)
+	case "double":
+		text, err := readText(dec, start)
+		if err != nil {
+			return nil, err
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("无效的浮点数: %q", text)
+		}
+		return f, nil
+	case "string":
+		return readText(dec, start)
+	case "dateTime.iso8601":
+		text, err := readText(dec, start)
+		if err != nil {
+			return nil, err
+		}
+		return parseDateTime(strings.TrimSpace(text))
+	case "base64":
+		text, err := readText(dec, start)
+		if err != nil {
+			return nil, err
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(text), ""))
+		if err != nil {
+			return nil, fmt.Errorf("无效的base64数据: %v", err)
+		}
+		return data, nil
+	case "nil":
+		return nil, dec.Skip()
+	case "struct":
+		return parseStruct(dec)
+	case "array":
+		return parseArray(dec)
+	default:
+		return nil, fmt.Errorf("不支持的XML-RPC类型: %s", start.Name.Local)
+	}
+}
+
+// readText 读取元素的文本内容
+func readText(dec *xml.Decoder, start xml.StartElement) (string, error) {
+	var text string
+	if err := dec.DecodeElement(&text, &start); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// parseDateTime 解析dateTime.iso8601，兼容常见的变体格式
+func parseDateTime(text string) (time.Time, error) {
+	layouts := []string{
+		xmlrpcDateTimeFormat,
+		"2006-01-02T15:04:05",
+		"20060102T15:04:05Z07:00",
+		time.RFC3339,
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无效的日期时间: %q", text)
+}
+
+// parseStruct 解析<struct>元素
+func parseStruct(dec *xml.Decoder) (interface{}, error) {
+	result := make(map[string]interface{})
+	for {
+		member, ok, err := nextStart(dec)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return result, nil
+		}
+		if member.Name.Local != "member" {
+			return nil, fmt.Errorf("struct中意外的元素 <%s>", member.Name.Local)
+		}
+
+		var name string
+		var value interface{}
+		hasName, hasValue := false, false
+		for {
+			elem, ok, err := nextStart(dec)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			switch elem.Name.Local {
+			case "name":
+				if name, err = readText(dec, elem); err != nil {
+					return nil, err
+				}
+				hasName = true
+			case "value":
+				if value, err = parseValue(dec); err != nil {
+					return nil, err
+				}
+				hasValue = true
+			default:
+				return nil, fmt.Errorf("member中意外的元素 <%s>", elem.Name.Local)
+			}
+		}
+		if !hasName || !hasValue {
+			return nil, fmt.Errorf("struct成员缺少name或value")
+		}
+		result[name] = value
+	}
+}
+
+// parseArray 解析<array>元素
+func parseArray(dec *xml.Decoder) (interface{}, error) {
+	result := []interface{}{}
+	data, ok, err := nextStart(dec)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return result, nil
+	}
+	if data.Name.Local != "data" {
+		return nil, fmt.Errorf("array中意外的元素 <%s>", data.Name.Local)
+	}
+	for {
+		elem, ok, err := nextStart(dec)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		if elem.Name.Local != "value" {
+			return nil, fmt.Errorf("array中意外的元素 <%s>", elem.Name.Local)
+		}
+		value, err := parseValue(dec)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	// 读取</array>
+	if _, ok, err := nextStart(dec); err != nil {
+		return nil, err
+	} else if ok {
+		return nil, fmt.Errorf("array中包含多个data元素")
+	}
+	return result, nil
+}
+
+// DecodeValue 将通用XML-RPC值解码到dst指向的Go值中
+//
+// 结构体成员按 xml 标签匹配，未知成员会被忽略。
+func DecodeValue(src interface{}, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("解码目标必须是非nil指针")
+	}
+	return decodeInto(src, rv.Elem())
+}
+
+// decodeInto 递归地将通用值写入目标
+func decodeInto(src interface{}, dst reflect.Value) error {
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if src == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decodeInto(src, dst.Elem())
+	case reflect.Interface:
+		if src == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		sv := reflect.ValueOf(src)
+		if !sv.Type().AssignableTo(dst.Type()) {
+			return decodeError(src, dst)
+		}
+		dst.Set(sv)
+		return nil
+	}
+
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	switch s := src.(type) {
+	case int:
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if dst.OverflowInt(int64(s)) {
+				return fmt.Errorf("整数 %d 超出 %s 的范围", s, dst.Type())
+			}
+			dst.SetInt(int64(s))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if s < 0 || dst.OverflowUint(uint64(s)) {
+				return fmt.Errorf("整数 %d 超出 %s 的范围", s, dst.Type())
+			}
+			dst.SetUint(uint64(s))
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(float64(s))
+		default:
+			return decodeError(src, dst)
+		}
+	case float64:
+		switch dst.Kind() {
+		case reflect.Float32, reflect.Float64:
+			dst.SetFloat(s)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if s != math.Trunc(s) || dst.OverflowInt(int64(s)) {
+				return decodeError(src, dst)
+			}
+			dst.SetInt(int64(s))
+		default:
+			return decodeError(src, dst)
+		}
+	case bool:
+		if dst.Kind() != reflect.Bool {
+			return decodeError(src, dst)
+		}
+		dst.SetBool(s)
+	case string:
+		switch {
+		case dst.Kind() == reflect.String:
+			dst.SetString(s)
+		case dst.Type() == bytesType:
+			dst.SetBytes([]byte(s))
+		default:
+			return decodeError(src, dst)
+		}
+	case time.Time:
+		if dst.Type() != timeType {
+			return decodeError(src, dst)
+		}
+		dst.Set(reflect.ValueOf(s))
+	case []byte:
+		switch {
+		case dst.Type() == bytesType:
+			dst.SetBytes(append([]byte(nil), s...))
+		case dst.Kind() == reflect.String:
+			dst.SetString(string(s))
+		default:
+			return decodeError(src, dst)
+		}
+	case []interface{}:
+		switch dst.Kind() {
+		case reflect.Slice:
+			slice := reflect.MakeSlice(dst.Type(), len(s), len(s))
+			for i, item := range s {
+				if err := decodeInto(item, slice.Index(i)); err != nil {
+					return fmt.Errorf("[%d]: %v", i, err)
+				}
+			}
+			dst.Set(slice)
+		case reflect.Array:
+			if len(s) != dst.Len() {
+				return fmt.Errorf("数组长度不匹配: 期望%d，实际%d", dst.Len(), len(s))
+			}
+			for i, item := range s {
+				if err := decodeInto(item, dst.Index(i)); err != nil {
+					return fmt.Errorf("[%d]: %v", i, err)
+				}
+			}
+		default:
+			return decodeError(src, dst)
+		}
+	case map[string]interface{}:
+		switch dst.Kind() {
+		case reflect.Map:
+			if dst.Type().Key().Kind() != reflect.String {
+				return decodeError(src, dst)
+			}
+			m := reflect.MakeMapWithSize(dst.Type(), len(s))
+			for key, item := range s {
+				elem := reflect.New(dst.Type().Elem()).Elem()
+				if err := decodeInto(item, elem); err != nil {
+					return fmt.Errorf("%s: %v", key, err)
+				}
+				m.SetMapIndex(reflect.ValueOf(key).Convert(dst.Type().Key()), elem)
+			}
+			dst.Set(m)
+		case reflect.Struct:
+			t := dst.Type()
+			for i := 0; i < t.NumField(); i++ {
+				name, _, ok := xmlrpcFieldName(t.Field(i))
+				if !ok {
+					continue
+				}
+				item, exists := s[name]
+				if !exists {
+					continue
+				}
+				if err := decodeInto(item, dst.Field(i)); err != nil {
+					return fmt.Errorf("%s: %v", name, err)
+				}
+			}
+		default:
+			return decodeError(src, dst)
+		}
+	default:
+		return decodeError(src, dst)
+	}
+	return nil
+}
+
+// decodeError 构造类型不匹配错误
+func decodeError(src interface{}, dst reflect.Value) error {
+	return fmt.Errorf("无法将XML-RPC值 %T 解码为 %s", src, dst.Type())
+}
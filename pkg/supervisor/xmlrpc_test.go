@@ -0,0 +1,153 @@
+package supervisor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wrapResponse 将value内容包装为XML-RPC响应
+func wrapResponse(value string) []byte {
+	return []byte(`<?xml version="1.0"?><methodResponse><params><param><value>` +
+		value + `</value></param></params></methodResponse>`)
+}
+
+// TestEncodeMethodCall 测试请求编码
+func TestEncodeMethodCall(t *testing.T) {
+	data, err := EncodeMethodCall("supervisor.startProcess", "web:web_00", true, 3, 1.5,
+		[]interface{}{"a", 0}, map[string]interface{}{"b": false, "a": nil}, []byte("hi"),
+		time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	body := string(data)
+	assert.Contains(t, body, "<methodName>supervisor.startProcess</methodName>")
+	assert.Contains(t, body, "<value><string>web:web_00</string></value>")
+	assert.Contains(t, body, "<value><boolean>1</boolean></value>")
+	assert.Contains(t, body, "<value><int>3</int></value>")
+	assert.Contains(t, body, "<value><double>1.5</double></value>")
+	assert.Contains(t, body, "<array><data><value><string>a</string></value><value><int>0</int></value></data></array>")
+	assert.Contains(t, body, "<struct><member><name>a</name><value><nil/></value></member><member><name>b</name><value><boolean>0</boolean></value></member></struct>")
+	assert.Contains(t, body, "<base64>aGk=</base64>")
+	assert.Contains(t, body, "<dateTime.iso8601>20240102T03:04:05</dateTime.iso8601>")
+}
+
+// TestEncodeMethodCall_EscapesStrings 测试字符串转义
+func TestEncodeMethodCall_EscapesStrings(t *testing.T) {
+	data, err := EncodeMethodCall("supervisor.sendProcessStdin", "a<b>&c")
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<string>a&lt;b&gt;&amp;c</string>")
+}
+
+// TestDecodeMethodResponse_Scalars 测试标量类型解码，包括零值
+func TestDecodeMethodResponse_Scalars(t *testing.T) {
+	var i int
+	require.NoError(t, DecodeMethodResponse(wrapResponse("<i4>0</i4>"), &i))
+	assert.Equal(t, 0, i)
+
+	var s string
+	require.NoError(t, DecodeMethodResponse(wrapResponse("<string></string>"), &s))
+	assert.Equal(t, "", s)
+
+	require.NoError(t, DecodeMethodResponse(wrapResponse("untyped"), &s))
+	assert.Equal(t, "untyped", s)
+
+	var b bool
+	require.NoError(t, DecodeMethodResponse(wrapResponse("<boolean>0</boolean>"), &b))
+	assert.False(t, b)
+
+	var f float64
+	require.NoError(t, DecodeMethodResponse(wrapResponse("<double>-2.25</double>"), &f))
+	assert.Equal(t, -2.25, f)
+
+	var ts time.Time
+	require.NoError(t, DecodeMethodResponse(wrapResponse("<dateTime.iso8601>20240102T03:04:05</dateTime.iso8601>"), &ts))
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), ts)
+
+	var raw []byte
+	require.NoError(t, DecodeMethodResponse(wrapResponse("<base64>aGVs\nbG8=</base64>"), &raw))
+	assert.Equal(t, []byte("hello"), raw)
+
+	var generic interface{} = "placeholder"
+	require.NoError(t, DecodeMethodResponse(wrapResponse("<nil/>"), &generic))
+	assert.Nil(t, generic)
+}
+
+// TestDecodeMethodResponse_ProcessInfo 测试解码到带标签的结构体
+func TestDecodeMethodResponse_ProcessInfo(t *testing.T) {
+	body := wrapResponse(`<array><data><value><struct>
+<member><name>name</name><value><string>web_00</string></value></member>
+<member><name>group</name><value><string>web</string></value></member>
+<member><name>state</name><value><int>0</int></value></member>
+<member><name>statename</name><value><string>STOPPED</string></value></member>
+<member><name>start</name><value><int>1700000000</int></value></member>
+<member><name>pid</name><value><int>0</int></value></member>
+<member><name>exitstatus</name><value><int>1</int></value></member>
+<member><name>spawnerr</name><value><string></string></value></member>
+<member><name>unknown</name><value><array><data/></array></value></member>
+</struct></value></data></array>`)
+
+	var infos []ProcessInfoRPC
+	require.NoError(t, DecodeMethodResponse(body, &infos))
+	require.Len(t, infos, 1)
+	assert.Equal(t, "web_00", infos[0].Name)
+	assert.Equal(t, "web", infos[0].Group)
+	assert.Equal(t, 0, infos[0].State)
+	assert.Equal(t, "STOPPED", infos[0].StateName)
+	assert.Equal(t, float64(1700000000), infos[0].Start)
+	assert.Equal(t, 1, infos[0].ExitStatus)
+}
+
+// TestDecodeMethodResponse_Generic 测试解码为通用值
+func TestDecodeMethodResponse_Generic(t *testing.T) {
+	body := wrapResponse(`<struct><member><name>list</name><value><array><data>
+<value><i4>1</i4></value><value><boolean>1</boolean></value><value>x</value>
+</data></array></value></member></struct>`)
+
+	var result map[string]interface{}
+	require.NoError(t, DecodeMethodResponse(body, &result))
+	assert.Equal(t, []interface{}{1, true, "x"}, result["list"])
+}
+
+// TestDecodeMethodResponse_Fault 测试fault响应
+func TestDecodeMethodResponse_Fault(t *testing.T) {
+	body := []byte(`<?xml version="1.0"?><methodResponse><fault><value><struct>
+<member><name>faultCode</name><value><int>10</int></value></member>
+<member><name>faultString</name><value><string>BAD_NAME: foo</string></value></member>
+</struct></value></fault></methodResponse>`)
+
+	err := DecodeMethodResponse(body, nil)
+	require.Error(t, err)
+	fault, ok := err.(*Fault)
+	require.True(t, ok)
+	assert.Equal(t, 10, fault.Code)
+	assert.Equal(t, "BAD_NAME: foo", fault.String)
+}
+
+// TestDecodeMethodResponse_TypeMismatch 测试类型不匹配时报错
+func TestDecodeMethodResponse_TypeMismatch(t *testing.T) {
+	var i int
+	err := DecodeMethodResponse(wrapResponse("<string>abc</string>"), &i)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "无法将XML-RPC值"))
+
+	err = DecodeMethodResponse([]byte("<html>bad gateway</html>"), &i)
+	assert.Error(t, err)
+}
+
+// TestEncodeDecodeRoundTrip 测试结构体编码后可以解码回来
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	info := ProcessInfoRPC{Name: "a&b", Group: "g", State: 20, Pid: 42, Start: 1.5}
+	data, err := EncodeMethodCall("echo", info)
+	require.NoError(t, err)
+
+	// 将请求参数改写为响应格式进行解码
+	body := strings.Replace(string(data), "<methodCall><methodName>echo</methodName>", "<methodResponse>", 1)
+	body = strings.Replace(body, "</methodCall>", "</methodResponse>", 1)
+
+	var decoded ProcessInfoRPC
+	require.NoError(t, DecodeMethodResponse([]byte(body), &decoded))
+	assert.Equal(t, info, decoded)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/renderer"
@@ -13,15 +14,20 @@ import (
 
 // ProcessInfo 表示一个进程的信息
 type ProcessInfo struct {
-	Index       int
-	Name        string
-	Group       string
-	State       int
-	StateName   string
-	PID         int
-	Uptime      string
-	Description string
-	ExitStatus  int
+	Index         int
+	Name          string
+	Group         string
+	State         int
+	StateName     string
+	PID           int
+	Uptime        string
+	Description   string
+	ExitStatus    int
+	SpawnErr      string    // 最近一次启动失败的原因
+	StartTime     time.Time // 最近一次启动的时间，从未启动时为零值
+	StopTime      time.Time // 最近一次停止的时间，从未停止时为零值
+	StdoutLogfile string
+	StderrLogfile string
 }
 
 // DisplayStatus 显示进程状态
@@ -76,16 +82,137 @@ func DisplayStatus(processes []ProcessInfo) {
 	table.Render()
 }
 
+// DisplayConfigChanges 以表格显示配置文件中新增、变更和删除的进程组
+func DisplayConfigChanges(added, changed, removed []string) {
+	table := tablewriter.NewTable(os.Stdout,
+		tablewriter.WithRenderer(renderer.NewBlueprint(tw.Rendition{
+			Symbols: tw.NewSymbols(tw.StyleLight),
+		})),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignCenter},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+		}),
+		tablewriter.WithTrimSpace(tw.Off),
+	)
+
+	table.Header([]string{"变更", "进程组", "操作"})
+
+	var data [][]any
+	for _, group := range added {
+		data = append(data, []any{"\x1b[32m➕ 新增\x1b[0m", group, "添加进程组"})
+	}
+	for _, group := range changed {
+		data = append(data, []any{"\x1b[33m🔄 变更\x1b[0m", group, "停止、移除后重新添加"})
+	}
+	for _, group := range removed {
+		data = append(data, []any{"\x1b[31m➖ 删除\x1b[0m", group, "停止并移除进程组"})
+	}
+
+	table.Bulk(data)
+	table.Render()
+}
+
+// DisplayStatusWide 显示进程状态，额外显示启动/停止时间、退出码、启动错误和日志文件
+func DisplayStatusWide(processes []ProcessInfo) {
+	if len(processes) == 0 {
+		fmt.Println("没有找到任何进程")
+		return
+	}
+
+	table := tablewriter.NewTable(os.Stdout,
+		tablewriter.WithRenderer(renderer.NewBlueprint(tw.Rendition{
+			Symbols: tw.NewSymbols(tw.StyleLight),
+		})),
+		tablewriter.WithConfig(tablewriter.Config{
+			Header: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignCenter},
+			},
+			Row: tw.CellConfig{
+				Alignment: tw.CellAlignment{Global: tw.AlignLeft},
+			},
+		}),
+		tablewriter.WithTrimSpace(tw.Off),
+	)
+
+	table.Header([]string{"序号", "名称", "状态", "PID", "运行时间", "启动时间", "停止时间", "退出码", "启动错误", "日志文件"})
+
+	var data [][]any
+	for _, proc := range processes {
+		pidStr := strconv.Itoa(proc.PID)
+		if proc.PID == 0 {
+			pidStr = "-"
+		}
+
+		// 只有进程停止后退出码才有意义
+		exitStr := "-"
+		if proc.PID == 0 && !proc.StopTime.IsZero() {
+			exitStr = strconv.Itoa(proc.ExitStatus)
+		}
+
+		spawnErr := proc.SpawnErr
+		if spawnErr == "" {
+			spawnErr = "-"
+		}
+
+		logfiles := proc.StdoutLogfile
+		if proc.StderrLogfile != "" && proc.StderrLogfile != proc.StdoutLogfile {
+			logfiles += "\n" + proc.StderrLogfile + " (stderr)"
+		}
+		if logfiles == "" {
+			logfiles = "-"
+		}
+
+		coloredStateName := fmt.Sprintf("%s%s%s", GetColorByState(proc.State), proc.StateName, "\x1b[0m")
+		data = append(data, []any{
+			proc.Index,
+			proc.Name,
+			coloredStateName,
+			pidStr,
+			proc.Uptime,
+			FormatTimestamp(proc.StartTime),
+			FormatTimestamp(proc.StopTime),
+			exitStr,
+			spawnErr,
+			logfiles,
+		})
+	}
+
+	table.Bulk(data)
+	table.Render()
+}
+
+// FormatTimestamp 格式化时间，零值显示为 "-"
+func FormatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+// Supervisor的进程状态码
+const (
+	ProcessStateStopped  = 0
+	ProcessStateStarting = 10
+	ProcessStateRunning  = 20
+	ProcessStateBackoff  = 30
+	ProcessStateStopping = 40
+	ProcessStateExited   = 100
+	ProcessStateFatal    = 200
+	ProcessStateUnknown  = 1000
+)
+
 // GetColorByState 根据状态获取颜色
 func GetColorByState(state int) string {
 	switch state {
-	case 20: // RUNNING
+	case ProcessStateRunning:
 		return "\x1b[32m" // 绿色
-	case 10: // STARTING
-		return "\x1b[33m" // 黄色
-	case 30: // STOPPING
+	case ProcessStateStarting, ProcessStateStopping, ProcessStateBackoff:
 		return "\x1b[33m" // 黄色
-	case 100: // FATAL
+	case ProcessStateFatal:
 		return "\x1b[31m" // 红色
 	default:
 		return "\x1b[37m" // 白色
@@ -95,40 +222,46 @@ func GetColorByState(state int) string {
 // GetStateIcon 获取状态图标
 func GetStateIcon(state int) string {
 	switch state {
-	case 20: // RUNNING
+	case ProcessStateRunning:
 		return "✅ 运行中"
-	case 10: // STARTING
+	case ProcessStateStarting:
 		return "🚀 启动中"
-	case 30: // STOPPING
+	case ProcessStateStopping:
 		return "⏹️ 停止中"
-	case 0: // STOPPED
+	case ProcessStateStopped:
 		return "⏸️ 已停止"
-	case 100: // FATAL
+	case ProcessStateExited:
+		return "🔚 已退出"
+	case ProcessStateFatal:
 		return "❌ 致命错误"
-	case 200: // BACKOFF
+	case ProcessStateBackoff:
 		return "⚠️ 重试中"
 	default:
 		return "❓ 未知"
 	}
 }
 
-// GetStateValue 根据状态名称获取状态代码
+// GetStateValue 根据状态名称获取状态代码，与XML-RPC返回的状态码一致
 func GetStateValue(stateName string) int {
 	switch strings.ToUpper(stateName) {
 	case "RUNNING":
-		return 20
+		return ProcessStateRunning
 	case "STARTING":
-		return 10
+		return ProcessStateStarting
 	case "STOPPING":
-		return 30
+		return ProcessStateStopping
 	case "STOPPED":
-		return 0
+		return ProcessStateStopped
+	case "EXITED":
+		return ProcessStateExited
 	case "FATAL":
-		return 100
+		return ProcessStateFatal
 	case "BACKOFF":
-		return 200
+		return ProcessStateBackoff
+	case "UNKNOWN":
+		return ProcessStateUnknown
 	default:
-		return 0
+		return ProcessStateStopped
 	}
 }
 
@@ -137,23 +270,22 @@ func ProcessUptimeString(uptime string) string {
 	return processUptimeString(uptime)
 }
 
-// processUptimeString 处理运行时间字符串，只保留时间部分
+// processUptimeString 处理运行时间字符串
 func processUptimeString(uptime string) string {
 	// 提取 "X days, X:X:X" 或 "X:X:X" 格式的运行时间
 	// 例如：从 "30 days, 16:17:38" 中提取 "30天16小时17分钟38秒"
 	// 或者从 "1:59:48" 中提取 "1小时59分钟48秒"
 
-	// 检查是否包含 "days" 信息
-	if strings.Contains(uptime, "days") {
-		parts := strings.Split(uptime, "days")
-		if len(parts) >= 2 {
+	// 检查是否包含天数信息（supervisor 对1天使用单数 "day"）
+	if idx := strings.Index(uptime, " day"); idx > 0 {
+		days, err := strconv.Atoi(strings.TrimSpace(uptime[:idx]))
+		if err == nil {
 			// 取逗号后的部分，即时间部分
-			timePart := strings.TrimSpace(parts[1])
-			if strings.HasPrefix(timePart, ",") {
-				timePart = strings.TrimSpace(timePart[1:])
-			}
+			timePart := uptime[idx+len(" day"):]
+			timePart = strings.TrimPrefix(timePart, "s")
+			timePart = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(timePart), ","))
 			// 解析 "HH:MM:SS" 格式
-			return parseTimeFormat(timePart)
+			return fmt.Sprintf("%d天%s", days, parseTimeFormat(timePart))
 		}
 	}
 
@@ -180,11 +312,7 @@ func parseTimeFormat(timeStr string) string {
 		secs, err3 := strconv.Atoi(timeComponents[2])
 
 		if err1 == nil && err2 == nil && err3 == nil {
-			if hours > 0 {
-				return fmt.Sprintf("%d小时%02d分钟%02d秒", hours, mins, secs)
-			} else {
-				return fmt.Sprintf("%02d分钟%02d秒", mins, secs)
-			}
+			return formatClock(hours, mins, secs)
 		}
 	} else if len(timeComponents) == 2 {
 		// MM:SS 格式
@@ -192,7 +320,7 @@ func parseTimeFormat(timeStr string) string {
 		secs, err2 := strconv.Atoi(timeComponents[1])
 
 		if err1 == nil && err2 == nil {
-			return fmt.Sprintf("%02d分钟%02d秒", mins, secs)
+			return formatClock(0, mins, secs)
 		}
 	}
 
@@ -200,6 +328,14 @@ func parseTimeFormat(timeStr string) string {
 	return timeStr
 }
 
+// formatClock 格式化不足一天的时间，与supervisord描述中的运行时间使用相同格式
+func formatClock(hours, mins, secs int) string {
+	if hours > 0 {
+		return fmt.Sprintf("%d小时%02d分钟%02d秒", hours, mins, secs)
+	}
+	return fmt.Sprintf("%02d分钟%02d秒", mins, secs)
+}
+
 // GetStringValue 从interface{}获取string值
 func GetStringValue(v interface{}) string {
 	if s, ok := v.(string); ok {
@@ -223,19 +359,11 @@ func FormatUptime(seconds int) string {
 	}
 
 	days := seconds / 86400
-	hours := (seconds % 86400) / 3600
-	minutes := (seconds % 3600) / 60
-	secondsRemaining := seconds % 60
-
+	clock := formatClock((seconds%86400)/3600, (seconds%3600)/60, seconds%60)
 	if days > 0 {
-		return fmt.Sprintf("%d天%d小时%02d分%02d秒", days, hours, minutes, secondsRemaining)
-	} else if hours > 0 {
-		return fmt.Sprintf("%d小时%02d分%02d秒", hours, minutes, secondsRemaining)
-	} else if minutes > 0 {
-		return fmt.Sprintf("%02d分钟%02d秒", minutes, secondsRemaining)
-	} else {
-		return fmt.Sprintf("%02d秒", secondsRemaining)
+		return fmt.Sprintf("%d天%s", days, clock)
 	}
+	return clock
 }
 
 // GetActionIcon 获取操作图标
@@ -247,6 +375,14 @@ func GetActionIcon(action string) string {
 		return "⏹️ 停止"
 	case "restart":
 		return "🔄 重启"
+	case "signal":
+		return "📡 信号"
+	case "added":
+		return "➕ 添加"
+	case "changed":
+		return "🔄 更新"
+	case "removed":
+		return "➖ 移除"
 	default:
 		return "⚙️ 操作"
 	}
@@ -278,89 +414,6 @@ func IsValidProcessLine(name string, rest string) bool {
 	return false
 }
 
-// ParseProcessIndices 解析进程索引参数
-func ParseProcessIndices(args []string, processes []ProcessInfo) ([]string, error) {
-	var names []string
-	var invalidIndices []int
-
-	for _, arg := range args {
-		// 检查是否为范围格式 (如: 1-5)
-		if strings.Contains(arg, "-") {
-			parts := strings.Split(arg, "-")
-			if len(parts) != 2 {
-				return nil, fmt.Errorf("无效的范围格式: %s", arg)
-			}
-
-			start, err1 := strconv.Atoi(parts[0])
-			end, err2 := strconv.Atoi(parts[1])
-			if err1 != nil || err2 != nil {
-				return nil, fmt.Errorf("无效的范围数字: %s", arg)
-			}
-
-			if start < 1 || end > len(processes) || start > end {
-				return nil, fmt.Errorf("范围超出有效区间: %s", arg)
-			}
-
-			for i := start; i <= end; i++ {
-				names = append(names, processes[i-1].Name)
-			}
-		} else {
-			// 单个数字
-			index, err := strconv.Atoi(arg)
-			if err != nil {
-				// 如果不是数字，检查是否为进程名（可能为简写或完整名称）
-				// 首先检查是否为完整名称（包含冒号）
-				if strings.Contains(arg, ":") {
-					// 这是一个完整的进程名，直接添加
-					names = append(names, arg)
-				} else {
-					// 这是一个简写名，尝试找到匹配的完整进程名
-					found := false
-					for _, proc := range processes {
-						// 检查是否与组名:进程名匹配
-						if strings.Contains(proc.Name, ":") && (proc.Name == arg ||
-							strings.Split(proc.Name, ":")[1] == arg) {
-							names = append(names, proc.Name)
-							found = true
-							break
-						}
-						// 或者直接匹配整个进程名
-						if proc.Name == arg {
-							names = append(names, proc.Name)
-							found = true
-							break
-						}
-					}
-					if !found {
-						// 如果找不到完全匹配，将原参数添加进去，让后续调用处理错误
-						names = append(names, arg)
-					}
-				}
-				continue
-			}
-
-			if index < 1 || index > len(processes) {
-				invalidIndices = append(invalidIndices, index)
-				continue
-			}
-
-			// 添加边界检查以避免索引越界
-			if index-1 >= len(processes) {
-				invalidIndices = append(invalidIndices, index)
-				continue
-			}
-
-			names = append(names, processes[index-1].Name)
-		}
-	}
-
-	if len(invalidIndices) > 0 {
-		return nil, fmt.Errorf("无效的进程序号: %v (有效范围: 1-%d)", invalidIndices, len(processes))
-	}
-
-	return names, nil
-}
-
 // ParseSupervisorctlOutput 解析 supervisorctl status 命令的输出
 func ParseSupervisorctlOutput(output string) []ProcessInfo {
 	lines := strings.Split(strings.TrimSpace(output), "\n")
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProcessUptimeString 测试包含天数的运行时间不会丢失天数
+func TestProcessUptimeString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"30 days, 16:17:38", "30天16小时17分钟38秒"},
+		{"1 day, 0:00:05", "1天00分钟05秒"},
+		{"1:59:48", "1小时59分钟48秒"},
+		{"0:05:03", "05分钟03秒"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ProcessUptimeString(tt.input), tt.input)
+	}
+}
+
+// TestFormatTimestamp 测试时间格式化
+func TestFormatTimestamp(t *testing.T) {
+	assert.Equal(t, "-", FormatTimestamp(time.Time{}))
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+	assert.Equal(t, "2024-01-02 03:04:05", FormatTimestamp(ts))
+}
+
+// TestProcessStates 测试每个状态的状态码、颜色和图标，XML-RPC和命令行输出使用相同的状态码
+func TestProcessStates(t *testing.T) {
+	const (
+		green  = "\x1b[32m"
+		yellow = "\x1b[33m"
+		red    = "\x1b[31m"
+		white  = "\x1b[37m"
+	)
+	tests := []struct {
+		name  string
+		state int
+		color string
+		icon  string
+	}{
+		{"STOPPED", 0, white, "⏸️ 已停止"},
+		{"STARTING", 10, yellow, "🚀 启动中"},
+		{"RUNNING", 20, green, "✅ 运行中"},
+		{"BACKOFF", 30, yellow, "⚠️ 重试中"},
+		{"STOPPING", 40, yellow, "⏹️ 停止中"},
+		{"EXITED", 100, white, "🔚 已退出"},
+		{"FATAL", 200, red, "❌ 致命错误"},
+		{"UNKNOWN", 1000, white, "❓ 未知"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.state, GetStateValue(tt.name))
+			assert.Equal(t, tt.state, GetStateValue(strings.ToLower(tt.name)))
+			assert.Equal(t, tt.color, GetColorByState(tt.state))
+			assert.Equal(t, tt.icon, GetStateIcon(tt.state))
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 日志格式
+const (
+	LogFormatText   = ""       // 纯文本
+	LogFormatJSON   = "json"   // JSON行
+	LogFormatLogfmt = "logfmt" // key=value
+)
+
+// 日志级别，数值越大越严重
+const (
+	LogLevelUnknown = -1
+	LogLevelTrace   = 0
+	LogLevelDebug   = 1
+	LogLevelInfo    = 2
+	LogLevelWarn    = 3
+	LogLevelError   = 4
+	LogLevelFatal   = 5
+)
+
+// 常见的时间、级别、消息字段名
+var (
+	logTimeKeys    = []string{"time", "ts", "timestamp", "@timestamp", "t"}
+	logLevelKeys   = []string{"level", "lvl", "severity", "loglevel", "@level"}
+	logMessageKeys = []string{"msg", "message", "@message"}
+)
+
+// LogField 结构化日志中的一个字段
+type LogField struct {
+	Key   string
+	Value string
+}
+
+// LogEntry 解析后的一行日志
+type LogEntry struct {
+	Raw     string     // 原始内容
+	Format  string     // LogFormatJSON、LogFormatLogfmt 或纯文本
+	Time    string     // 时间字段
+	Level   int        // 日志级别，无法识别时为 LogLevelUnknown
+	Message string     // 消息字段
+	Fields  []LogField // 其余字段，保持原始顺序
+}
+
+// ParseLogLine 识别JSON和logfmt格式的日志行，其他内容按纯文本处理
+func ParseLogLine(line string) LogEntry {
+	trimmed := strings.TrimSpace(line)
+	if strings.HasPrefix(trimmed, "{") {
+		if fields, ok := parseJSONFields(trimmed); ok {
+			return newStructuredEntry(line, LogFormatJSON, fields)
+		}
+	}
+	if fields, ok := parseLogfmtFields(trimmed); ok {
+		return newStructuredEntry(line, LogFormatLogfmt, fields)
+	}
+	return LogEntry{Raw: line, Level: LogLevelUnknown}
+}
+
+// newStructuredEntry 从字段中提取时间、级别和消息
+func newStructuredEntry(raw, format string, fields []LogField) LogEntry {
+	entry := LogEntry{Raw: raw, Format: format, Level: LogLevelUnknown}
+	levelText := ""
+	for _, field := range fields {
+		key := strings.ToLower(field.Key)
+		switch {
+		case entry.Time == "" && containsString(logTimeKeys, key):
+			entry.Time = field.Value
+		case levelText == "" && containsString(logLevelKeys, key):
+			levelText = field.Value
+		case entry.Message == "" && containsString(logMessageKeys, key):
+			entry.Message = field.Value
+		default:
+			entry.Fields = append(entry.Fields, field)
+		}
+	}
+	if levelText != "" {
+		entry.Level = ParseLogLevel(levelText)
+	}
+	return entry
+}
+
+// containsString 检查切片中是否包含s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJSONFields 按原始顺序解析JSON对象的顶层字段，嵌套值保留为紧凑的JSON
+func parseJSONFields(line string) ([]LogField, bool) {
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+
+	var fields []LogField
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, false
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, false
+		}
+		fields = append(fields, LogField{Key: key, Value: jsonValueString(raw)})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, false
+	}
+	// 对象后不能再有其他内容
+	if dec.InputOffset() != int64(len(line)) {
+		return nil, false
+	}
+	return fields, true
+}
+
+// jsonValueString 将JSON值转换为显示用的字符串
+func jsonValueString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err == nil {
+		return buf.String()
+	}
+	return string(raw)
+}
+
+// logfmtKey logfmt的键名
+var logfmtKey = regexp.MustCompile(`^[A-Za-z_@][A-Za-z0-9_.\-@/]*$`)
+
+// parseLogfmtFields 解析 key=value 格式的日志行
+// 为避免把普通文本误判为logfmt，要求每一项都是 key=value 且至少有两项
+func parseLogfmtFields(line string) ([]LogField, bool) {
+	var fields []LogField
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+
+		eq := strings.IndexByte(line[i:], '=')
+		if eq <= 0 {
+			return nil, false
+		}
+		key := line[i : i+eq]
+		if !logfmtKey.MatchString(key) {
+			return nil, false
+		}
+		i += eq + 1
+
+		var value string
+		if i < len(line) && line[i] == '"' {
+			end := i + 1
+			for end < len(line) && line[end] != '"' {
+				if line[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(line) {
+				return nil, false
+			}
+			unquoted, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, false
+			}
+			value = unquoted
+			i = end + 1
+			if i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				return nil, false
+			}
+		} else {
+			end := strings.IndexAny(line[i:], " \t")
+			if end < 0 {
+				end = len(line) - i
+			}
+			value = line[i : i+end]
+			if strings.ContainsRune(value, '"') {
+				return nil, false
+			}
+			i += end
+		}
+		fields = append(fields, LogField{Key: key, Value: value})
+	}
+	return fields, len(fields) >= 2
+}
+
+// ParseLogLevel 将级别名称或数字（如pino的30/40/50）转换为日志级别
+func ParseLogLevel(level string) int {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "trace", "trc", "10":
+		return LogLevelTrace
+	case "debug", "dbg", "d", "20":
+		return LogLevelDebug
+	case "info", "inf", "i", "notice", "30":
+		return LogLevelInfo
+	case "warn", "warning", "wrn", "w", "40":
+		return LogLevelWarn
+	case "error", "err", "e", "50":
+		return LogLevelError
+	case "fatal", "critical", "crit", "panic", "alert", "emerg", "emergency", "f", "60":
+		return LogLevelFatal
+	default:
+		return LogLevelUnknown
+	}
+}
+
+// LogLevelName 返回日志级别的显示名称
+func LogLevelName(level int) string {
+	switch level {
+	case LogLevelTrace:
+		return "TRACE"
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	case LogLevelFatal:
+		return "FATAL"
+	default:
+		return "-"
+	}
+}
+
+// GetColorByLogLevel 根据日志级别获取颜色
+func GetColorByLogLevel(level int) string {
+	switch level {
+	case LogLevelInfo:
+		return "\x1b[32m" // 绿色
+	case LogLevelWarn:
+		return "\x1b[33m" // 黄色
+	case LogLevelError, LogLevelFatal:
+		return "\x1b[31m" // 红色
+	default:
+		return "\x1b[37m" // 白色
+	}
+}
+
+// FormatLogEntry 格式化日志行，结构化日志显示为 "时间 级别 消息 key=value"，纯文本原样返回
+func FormatLogEntry(entry LogEntry) string {
+	if entry.Format == LogFormatText {
+		return entry.Raw
+	}
+
+	var parts []string
+	if entry.Time != "" {
+		parts = append(parts, "\x1b[90m"+entry.Time+"\x1b[0m")
+	}
+	if entry.Level != LogLevelUnknown {
+		parts = append(parts, fmt.Sprintf("%s%-5s\x1b[0m", GetColorByLogLevel(entry.Level), LogLevelName(entry.Level)))
+	}
+	if entry.Message != "" {
+		parts = append(parts, entry.Message)
+	}
+	for _, field := range entry.Fields {
+		parts = append(parts, "\x1b[36m"+field.Key+"\x1b[0m="+quoteLogfmtValue(field.Value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteLogfmtValue 值包含空白或引号时加引号
+func quoteLogfmtValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\"=") {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// LogFilter 日志过滤条件
+type LogFilter struct {
+	MinLevel int            // 最低日志级别，LogLevelUnknown 表示不过滤
+	Grep     *regexp.Regexp // 匹配消息或任意 key=value 字段，nil 表示不过滤
+}
+
+// Match 检查日志行是否满足过滤条件
+// 无法识别级别的行（纯文本、异常堆栈等）不按级别过滤
+func (f LogFilter) Match(entry LogEntry) bool {
+	if f.MinLevel != LogLevelUnknown && entry.Level != LogLevelUnknown && entry.Level < f.MinLevel {
+		return false
+	}
+	if f.Grep == nil {
+		return true
+	}
+	if entry.Format == LogFormatText {
+		return f.Grep.MatchString(entry.Raw)
+	}
+	if f.Grep.MatchString(entry.Message) {
+		return true
+	}
+	for _, field := range entry.Fields {
+		if f.Grep.MatchString(field.Key + "=" + field.Value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseLogLine 测试JSON、logfmt和纯文本的识别
+func TestParseLogLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected LogEntry
+	}{
+		{
+			name: "JSON",
+			line: `{"time":"2024-01-02T03:04:05Z","level":"warn","msg":"slow query","ms":1200,"tags":["db"]}`,
+			expected: LogEntry{
+				Format: LogFormatJSON, Time: "2024-01-02T03:04:05Z", Level: LogLevelWarn, Message: "slow query",
+				Fields: []LogField{{Key: "ms", Value: "1200"}, {Key: "tags", Value: `["db"]`}},
+			},
+		},
+		{
+			name:     "pino数字级别",
+			line:     `{"level":50,"msg":"boom"}`,
+			expected: LogEntry{Format: LogFormatJSON, Level: LogLevelError, Message: "boom"},
+		},
+		{
+			name: "logfmt",
+			line: `ts=12:00 lvl=info msg="user login" user_id=42`,
+			expected: LogEntry{
+				Format: LogFormatLogfmt, Time: "12:00", Level: LogLevelInfo, Message: "user login",
+				Fields: []LogField{{Key: "user_id", Value: "42"}},
+			},
+		},
+		{
+			name:     "纯文本",
+			line:     "Listening on port=8080",
+			expected: LogEntry{Level: LogLevelUnknown},
+		},
+		{
+			name:     "不完整的JSON",
+			line:     `{"level":"info"`,
+			expected: LogEntry{Level: LogLevelUnknown},
+		},
+		{
+			name:     "JSON后有多余内容",
+			line:     `{"a":1} trailing`,
+			expected: LogEntry{Level: LogLevelUnknown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.expected.Raw = tt.line
+			assert.Equal(t, tt.expected, ParseLogLine(tt.line))
+		})
+	}
+}
+
+// TestFormatLogEntry 测试结构化日志的格式化，纯文本原样输出
+func TestFormatLogEntry(t *testing.T) {
+	assert.Equal(t, "plain text", FormatLogEntry(ParseLogLine("plain text")))
+
+	formatted := FormatLogEntry(ParseLogLine(`{"level":"error","msg":"failed","path":"/a b"}`))
+	assert.Equal(t, "\x1b[31mERROR\x1b[0m failed \x1b[36mpath\x1b[0m=\"/a b\"", formatted)
+}
+
+// TestLogFilter 测试级别和正则过滤
+func TestLogFilter(t *testing.T) {
+	warn := LogFilter{MinLevel: LogLevelWarn}
+	assert.False(t, warn.Match(ParseLogLine(`{"level":"info","msg":"ok"}`)))
+	assert.True(t, warn.Match(ParseLogLine(`level=error msg=bad`)))
+	assert.True(t, warn.Match(ParseLogLine("traceback line")), "无法识别级别的行不按级别过滤")
+
+	grep := LogFilter{MinLevel: LogLevelUnknown, Grep: regexp.MustCompile(`user_id=42\b`)}
+	assert.True(t, grep.Match(ParseLogLine(`{"msg":"login","user_id":42}`)))
+	assert.False(t, grep.Match(ParseLogLine(`{"msg":"login","user_id":420}`)))
+	assert.True(t, grep.Match(ParseLogLine("plain user_id=42 text")))
+}
+
+// TestParseLogLevel 测试日志级别名称解析
+func TestParseLogLevel(t *testing.T) {
+	assert.Equal(t, LogLevelWarn, ParseLogLevel("WARNING"))
+	assert.Equal(t, LogLevelFatal, ParseLogLevel("critical"))
+	assert.Equal(t, LogLevelDebug, ParseLogLevel("20"))
+	assert.Equal(t, LogLevelUnknown, ParseLogLevel("verbose"))
+}
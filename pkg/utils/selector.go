@@ -0,0 +1,413 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 进程选择器
+const (
+	SelectAll      = "all"      // 所有进程
+	SelectFailed   = "--failed" // 处于 FATAL 或 BACKOFF 状态的进程
+	statePrefix    = "state="   // 按状态选择，例如 state=FATAL 或 state=FATAL,EXITED
+	regexPrefix    = "~"        // 按正则表达式匹配完整进程名，例如 ~^api-
+	excludePrefix  = "!"        // 排除，例如 !3 或 !web:*
+	excludeFlag    = "-x"       // 排除下一个参数，例如 -x web:*
+	excludeLongArg = "--exclude"
+)
+
+// failedStates --failed 选择的进程状态
+var failedStates = []string{"FATAL", "BACKOFF"}
+
+// processStateNames supervisord的所有进程状态名称
+var processStateNames = []string{"STOPPED", "STARTING", "RUNNING", "BACKOFF", "STOPPING", "EXITED", "FATAL", "UNKNOWN"}
+
+// rangePattern 只由数字和 "-" 组成的参数按序号或范围解析，避免与 api-server 这样的进程名冲突
+var rangePattern = regexp.MustCompile(`^[0-9][0-9-]*$`)
+
+// ParseProcessIndices 解析进程参数，返回去重后的进程名
+//
+// 支持的参数：
+//   - 序号和范围：3、2-5、5-（第5个到最后一个）
+//   - 进程名：web:web_00、简写 web_00，简写名有歧义或找不到进程时返回错误；
+//     与进程名完全相同的数字参数（如名为 2024 的程序）按进程名处理
+//   - all、group:*、进程组名（web 等同于 web:*）、通配符（api-*）和正则表达式（~^api-）
+//   - 状态：state=FATAL、state=FATAL,EXITED、--failed
+//   - 排除：!3、!web:*、-x web:*、--exclude web:*，只有排除参数时从所有进程中排除
+func ParseProcessIndices(args []string, processes []ProcessInfo) ([]string, error) {
+	var includes, excludes []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == excludeFlag || arg == excludeLongArg:
+			if i+1 >= len(args) {
+				return nil, fmt.Errorf("%s 需要指定要排除的进程", arg)
+			}
+			i++
+			excludes = append(excludes, args[i])
+		case strings.HasPrefix(arg, excludeLongArg+"="):
+			excludes = append(excludes, strings.TrimPrefix(arg, excludeLongArg+"="))
+		case strings.HasPrefix(arg, excludePrefix) && len(arg) > len(excludePrefix):
+			excludes = append(excludes, strings.TrimPrefix(arg, excludePrefix))
+		default:
+			includes = append(includes, arg)
+		}
+	}
+	if len(includes) == 0 && len(excludes) > 0 {
+		includes = []string{SelectAll}
+	}
+
+	names, err := resolveSelectors(includes, processes)
+	if err != nil {
+		return nil, err
+	}
+	if len(excludes) == 0 {
+		return names, nil
+	}
+
+	excluded, err := resolveSelectors(excludes, processes)
+	if err != nil {
+		return nil, fmt.Errorf("解析排除参数失败: %w", err)
+	}
+	skip := make(map[string]bool, len(excluded))
+	for _, name := range excluded {
+		skip[name] = true
+	}
+	var result []string
+	for _, name := range names {
+		if !skip[name] {
+			result = append(result, name)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("排除 %v 后没有剩余的进程", excludes)
+	}
+	return result, nil
+}
+
+// HasExclusion 检查参数中是否包含排除选择器
+func HasExclusion(args []string) bool {
+	for _, arg := range args {
+		if arg == excludeFlag || arg == excludeLongArg || strings.HasPrefix(arg, excludeLongArg+"=") ||
+			(strings.HasPrefix(arg, excludePrefix) && len(arg) > len(excludePrefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesIndices 检查参数中是否有依赖进程列表顺序的序号或范围（包括排除参数）
+func UsesIndices(args []string) bool {
+	for _, arg := range args {
+		arg = strings.TrimPrefix(arg, excludePrefix)
+		arg = strings.TrimPrefix(arg, excludeLongArg+"=")
+		if rangePattern.MatchString(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveSelectors 将选择器解析为进程名，按出现顺序去重
+func resolveSelectors(selectors []string, processes []ProcessInfo) ([]string, error) {
+	var names []string
+	var invalidIndices []int
+	var unmatched []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, selector := range selectors {
+		if rangePattern.MatchString(selector) {
+			// 与进程名或进程组名相同时按名称处理
+			if name, err := resolveProcessName(selector, processes); err == nil && len(processes) > 0 {
+				add(name)
+				continue
+			}
+			if members := groupMembers(selector, processes); len(members) > 0 {
+				for _, name := range members {
+					add(name)
+				}
+				continue
+			}
+			if !strings.Contains(selector, "-") {
+				index, err := strconv.Atoi(selector)
+				if err != nil || index < 1 || index > len(processes) {
+					invalidIndices = append(invalidIndices, index)
+					continue
+				}
+				add(processes[index-1].Name)
+				continue
+			}
+
+			start, end, err := parseRange(selector, len(processes))
+			if err != nil {
+				return nil, err
+			}
+			for i := start; i <= end; i++ {
+				add(processes[i-1].Name)
+			}
+			continue
+		}
+
+		matched, isPattern, err := matchSelector(selector, processes)
+		if err != nil {
+			return nil, err
+		}
+		if !isPattern {
+			// 进程组名等同于 group:*，但与进程的完整名称相同时按进程处理
+			if members := groupMembers(selector, processes); len(members) > 0 && !hasFullName(selector, processes) {
+				if others := shortNameMatches(selector, processes, selector); len(others) > 0 {
+					return nil, fmt.Errorf("进程名 %s 有歧义，同时匹配进程组 %s:* 和其他进程组中的进程: %s，请使用完整名称或序号",
+						selector, selector, strings.Join(others, ", "))
+				}
+				for _, name := range members {
+					add(name)
+				}
+				continue
+			}
+			name, err := resolveProcessName(selector, processes)
+			if err != nil {
+				return nil, err
+			}
+			add(name)
+			continue
+		}
+		if len(matched) == 0 {
+			unmatched = append(unmatched, selector)
+		}
+		for _, name := range matched {
+			add(name)
+		}
+	}
+
+	if len(invalidIndices) > 0 {
+		return nil, fmt.Errorf("无效的进程序号: %v (有效范围: 1-%d)", invalidIndices, len(processes))
+	}
+	// 部分选择器没有匹配时只要还有其他进程被选中就继续，全部为空时报告
+	if len(names) == 0 && len(unmatched) > 0 {
+		return nil, fmt.Errorf("没有匹配 %s 的进程", strings.Join(unmatched, " "))
+	}
+	return names, nil
+}
+
+// parseRange 解析 a-b 和 a- 形式的范围
+func parseRange(selector string, count int) (int, int, error) {
+	parts := strings.Split(selector, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("无效的范围格式: %s", selector)
+	}
+
+	start, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("无效的范围数字: %s", selector)
+	}
+	end := count
+	if parts[1] != "" {
+		if end, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, fmt.Errorf("无效的范围数字: %s", selector)
+		}
+	}
+
+	if start < 1 || end > count || start > end {
+		return 0, 0, fmt.Errorf("范围超出有效区间: %s (有效范围: 1-%d)", selector, count)
+	}
+	return start, end, nil
+}
+
+// matchSelector 匹配 all、状态、正则表达式和通配符选择器
+// 不是这些选择器时isPattern为false，参数按进程名处理
+func matchSelector(selector string, processes []ProcessInfo) (matched []string, isPattern bool, err error) {
+	var match func(p ProcessInfo) bool
+
+	switch {
+	case selector == SelectAll:
+		match = func(ProcessInfo) bool { return true }
+	case selector == SelectFailed:
+		match = func(p ProcessInfo) bool { return containsString(failedStates, strings.ToUpper(p.StateName)) }
+	case strings.HasPrefix(selector, statePrefix):
+		states := strings.Split(strings.ToUpper(strings.TrimPrefix(selector, statePrefix)), ",")
+		for _, state := range states {
+			if !containsString(processStateNames, state) {
+				return nil, true, fmt.Errorf("未知的进程状态: %s (可用状态: %s)", state, strings.Join(processStateNames, ", "))
+			}
+		}
+		match = func(p ProcessInfo) bool { return containsString(states, strings.ToUpper(p.StateName)) }
+	case strings.HasPrefix(selector, regexPrefix):
+		re, err := regexp.Compile(strings.TrimPrefix(selector, regexPrefix))
+		if err != nil {
+			return nil, true, fmt.Errorf("无效的正则表达式 %s: %v", selector, err)
+		}
+		match = func(p ProcessInfo) bool { return re.MatchString(p.Name) }
+	case strings.ContainsAny(selector, "*?["):
+		if _, err := path.Match(selector, ""); err != nil {
+			return nil, true, fmt.Errorf("无效的通配符 %s: %v", selector, err)
+		}
+		match = func(p ProcessInfo) bool { return globMatch(selector, p.Name) }
+	default:
+		return nil, false, nil
+	}
+
+	for _, p := range processes {
+		if match(p) {
+			matched = append(matched, p.Name)
+		}
+	}
+	return matched, true, nil
+}
+
+// globMatch 通配符匹配完整进程名；不含 ":" 的模式也匹配简写名，
+// supervisorctl 输出中单进程组的进程名没有组名前缀，此时按 name:name 匹配
+func globMatch(pattern, name string) bool {
+	group, short, hasGroup := strings.Cut(name, ":")
+	if !hasGroup {
+		short = name
+		group = name
+	}
+	if ok, _ := path.Match(pattern, group+":"+short); ok {
+		return true
+	}
+	if strings.Contains(pattern, ":") {
+		return false
+	}
+	ok, _ := path.Match(pattern, short)
+	return ok
+}
+
+// groupMembers 返回进程组group中的所有进程，参数不是进程组名时返回空
+func groupMembers(group string, processes []ProcessInfo) []string {
+	if strings.Contains(group, ":") {
+		return nil
+	}
+	var members []string
+	for _, proc := range processes {
+		if g, _, ok := strings.Cut(proc.Name, ":"); ok && g == group {
+			members = append(members, proc.Name)
+		}
+	}
+	return members
+}
+
+// hasFullName 检查是否有进程的完整名称与name相同
+func hasFullName(name string, processes []ProcessInfo) bool {
+	for _, proc := range processes {
+		if proc.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// shortNameMatches 返回简写名为name、且不在进程组exceptGroup中的进程
+func shortNameMatches(name string, processes []ProcessInfo, exceptGroup string) []string {
+	var matches []string
+	for _, proc := range processes {
+		if group, short, ok := strings.Cut(proc.Name, ":"); ok && short == name && group != exceptGroup {
+			matches = append(matches, proc.Name)
+		}
+	}
+	return matches
+}
+
+// resolveProcessName 将完整或简写的进程名解析为完整进程名
+//
+// 简写名在多个进程组中存在时报告歧义并列出候选；找不到时根据编辑距离给出建议。
+// 无法获取进程列表时无法校验，原样返回由后续调用报告错误。
+func resolveProcessName(name string, processes []ProcessInfo) (string, error) {
+	if len(processes) == 0 {
+		return name, nil
+	}
+
+	var candidates []string
+	for _, proc := range processes {
+		group, short, hasGroup := strings.Cut(proc.Name, ":")
+		if !hasGroup {
+			// supervisorctl 输出中单进程组的进程名没有组名前缀
+			group, short = proc.Name, proc.Name
+		}
+		if proc.Name == name || group+":"+short == name {
+			return proc.Name, nil
+		}
+		if short == name {
+			candidates = append(candidates, proc.Name)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		if suggestions := suggestProcessNames(name, processes); len(suggestions) > 0 {
+			return "", fmt.Errorf("未找到进程 %s，您是不是要找: %s", name, strings.Join(suggestions, ", "))
+		}
+		return "", fmt.Errorf("未找到进程 %s", name)
+	case 1:
+		return candidates[0], nil
+	default:
+		return "", fmt.Errorf("进程名 %s 有歧义，匹配多个进程组中的进程: %s，请使用完整名称或序号",
+			name, strings.Join(candidates, ", "))
+	}
+}
+
+// maxSuggestions 最多给出的建议数量
+const maxSuggestions = 3
+
+// suggestProcessNames 返回与名称编辑距离最近的进程名，按距离和名称排序
+func suggestProcessNames(name string, processes []ProcessInfo) []string {
+	type suggestion struct {
+		name     string
+		distance int
+	}
+	// 允许的距离随名称长度增长，避免短名称匹配到不相关的进程
+	limit := len(name)/3 + 1
+	var suggestions []suggestion
+	for _, proc := range processes {
+		distance := editDistance(name, proc.Name)
+		if _, short, ok := strings.Cut(proc.Name, ":"); ok {
+			distance = min(distance, editDistance(name, short))
+		}
+		if distance <= limit {
+			suggestions = append(suggestions, suggestion{proc.Name, distance})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].name < suggestions[j].name
+	})
+	var names []string
+	for i := 0; i < len(suggestions) && i < maxSuggestions; i++ {
+		names = append(names, suggestions[i].name)
+	}
+	return names
+}
+
+// editDistance 计算两个字符串之间的Levenshtein距离
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
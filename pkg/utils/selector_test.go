@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// selectorProcesses 测试用的进程列表，顺序即序号
+var selectorProcesses = []ProcessInfo{
+	{Index: 1, Name: "api-server:api-server", StateName: "RUNNING"},
+	{Index: 2, Name: "api-worker:api-worker", StateName: "FATAL"},
+	{Index: 3, Name: "web:web_00", StateName: "RUNNING"},
+	{Index: 4, Name: "web:web_01", StateName: "BACKOFF"},
+	{Index: 5, Name: "cron", StateName: "EXITED"},
+}
+
+// TestParseProcessIndices_Selectors 测试各种进程选择器
+func TestParseProcessIndices_Selectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{"序号和范围", []string{"1", "3-4"}, []string{"api-server:api-server", "web:web_00", "web:web_01"}},
+		{"开放范围", []string{"4-"}, []string{"web:web_01", "cron"}},
+		{"带连字符的简写名", []string{"api-server"}, []string{"api-server:api-server"}},
+		{"无组名前缀的完整名称", []string{"cron:cron"}, []string{"cron"}},
+		{"all", []string{"all"}, []string{"api-server:api-server", "api-worker:api-worker", "web:web_00", "web:web_01", "cron"}},
+		{"进程组", []string{"web:*"}, []string{"web:web_00", "web:web_01"}},
+		{"无组名前缀的进程组", []string{"cron:*"}, []string{"cron"}},
+		{"通配符", []string{"api-*"}, []string{"api-server:api-server", "api-worker:api-worker"}},
+		{"正则表达式", []string{"~_0[1-9]$"}, []string{"web:web_01"}},
+		{"状态", []string{"state=running"}, []string{"api-server:api-server", "web:web_00"}},
+		{"多个状态", []string{"state=EXITED,FATAL"}, []string{"api-worker:api-worker", "cron"}},
+		{"失败的进程", []string{"--failed"}, []string{"api-worker:api-worker", "web:web_01"}},
+		{"去重", []string{"3", "web:*", "web_00"}, []string{"web:web_00", "web:web_01"}},
+		{"排除序号", []string{"1-3", "!2"}, []string{"api-server:api-server", "web:web_00"}},
+		{"-x 排除", []string{"all", "-x", "web:*", "--exclude=cron"}, []string{"api-server:api-server", "api-worker:api-worker"}},
+		{"只有排除参数", []string{"!api-*"}, []string{"web:web_00", "web:web_01", "cron"}},
+		{"部分选择器无匹配", []string{"state=STOPPED", "cron"}, []string{"cron"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := ParseProcessIndices(tt.args, selectorProcesses)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+// TestParseProcessIndices_SelectorErrors 测试无效的选择器
+func TestParseProcessIndices_SelectorErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"无效范围格式", []string{"1-2-3"}},
+		{"未知进程名", []string{"nginx"}},
+		{"未知的完整进程名", []string{"web:web_02"}},
+		{"开放范围超出", []string{"6-"}},
+		{"序号超出", []string{"0", "9"}},
+		{"未知状态", []string{"state=DEAD"}},
+		{"无效正则", []string{"~("}},
+		{"无效通配符", []string{"web["}},
+		{"没有匹配", []string{"db-*"}},
+		{"-x 缺少参数", []string{"all", "-x"}},
+		{"全部排除", []string{"web:*", "!web_*"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := ParseProcessIndices(tt.args, selectorProcesses)
+			assert.Error(t, err)
+			assert.Nil(t, names)
+		})
+	}
+}
+
+// TestHasExclusion 测试检测排除参数
+func TestHasExclusion(t *testing.T) {
+	assert.True(t, HasExclusion([]string{"all", "!3"}))
+	assert.True(t, HasExclusion([]string{"web:*", "-x", "web:web_00"}))
+	assert.True(t, HasExclusion([]string{"--exclude=cron"}))
+	assert.False(t, HasExclusion([]string{"all", "web:*", "!"}))
+}
+
+// TestUsesIndices 测试检测序号参数
+func TestUsesIndices(t *testing.T) {
+	assert.True(t, UsesIndices([]string{"web:*", "3"}))
+	assert.True(t, UsesIndices([]string{"all", "!5-"}))
+	assert.True(t, UsesIndices([]string{"all", "-x", "2"}))
+	assert.False(t, UsesIndices([]string{"api-server", "web:*", "state=FATAL", "--failed"}))
+}
+
+// TestParseProcessIndices_ShortNames 测试简写名的歧义和拼写建议
+func TestParseProcessIndices_ShortNames(t *testing.T) {
+	processes := []ProcessInfo{
+		{Index: 1, Name: "billing:worker"},
+		{Index: 2, Name: "mailer:worker"},
+		{Index: 3, Name: "web:web_00"},
+		{Index: 4, Name: "worker:scheduler"},
+	}
+
+	_, err := ParseProcessIndices([]string{"3", "worker"}, processes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "有歧义")
+	assert.Contains(t, err.Error(), "billing:worker, mailer:worker")
+
+	names, err := ParseProcessIndices([]string{"mailer:worker", "scheduler"}, processes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mailer:worker", "worker:scheduler"}, names)
+
+	_, err = ParseProcessIndices([]string{"web_0"}, processes)
+	require.Error(t, err)
+	assert.Equal(t, "未找到进程 web_0，您是不是要找: web:web_00", err.Error())
+
+	_, err = ParseProcessIndices([]string{"shceduler"}, processes)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "您是不是要找: worker:scheduler")
+
+	_, err = ParseProcessIndices([]string{"database"}, processes)
+	require.Error(t, err)
+	assert.Equal(t, "未找到进程 database", err.Error())
+
+	// 无法获取进程列表时不校验名称
+	names, err = ParseProcessIndices([]string{"nginx"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nginx"}, names)
+}
+
+// TestEditDistance 测试编辑距离
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("web", "web"))
+	assert.Equal(t, 1, editDistance("nginz", "nginx"))
+	assert.Equal(t, 2, editDistance("shceduler", "scheduler"))
+	assert.Equal(t, 3, editDistance("", "api"))
+	assert.Equal(t, 1, editDistance("服务", "服务器"))
+}
+
+// TestParseProcessIndices_NamesBeforeIndices 测试数字进程名和进程组名
+func TestParseProcessIndices_NamesBeforeIndices(t *testing.T) {
+	processes := []ProcessInfo{
+		{Index: 1, Name: "2024:2024"},
+		{Index: 2, Name: "web:web_00"},
+		{Index: 3, Name: "web:web_01"},
+		{Index: 4, Name: "cron"},
+	}
+
+	// 与进程名相同的数字按进程名处理，其他数字仍按序号处理
+	names, err := ParseProcessIndices([]string{"2024", "4"}, processes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024:2024", "cron"}, names)
+
+	// 进程组名等同于 group:*
+	names, err = ParseProcessIndices([]string{"web"}, processes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web:web_00", "web:web_01"}, names)
+
+	names, err = ParseProcessIndices([]string{"all", "!web"}, processes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2024:2024", "cron"}, names)
+
+	// 单进程组的进程名与组名相同
+	names, err = ParseProcessIndices([]string{"cron"}, processes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cron"}, names)
+}
@@ -177,14 +177,20 @@ func (suite *ProcessControlTestSuite) TestParseProcessIndices() {
 		},
 		{
 			name:     "进程名称",
-			args:     []string{"nginx"},
-			expected: []string{"nginx"},
+			args:     []string{"process2"},
+			expected: []string{"process2"},
 			hasError: false,
 		},
+		{
+			name:     "未知进程名称",
+			args:     []string{"nginx"},
+			expected: nil,
+			hasError: true,
+		},
 		{
 			name:     "混合",
-			args:     []string{"1", "nginx", "3-4"},
-			expected: []string{"process1", "nginx", "process3", "process4"},
+			args:     []string{"1", "process2", "3-4"},
+			expected: []string{"process1", "process2", "process3", "process4"},
 			hasError: false,
 		},
 		{